@@ -0,0 +1,326 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/config"
+)
+
+// Create writes a gzipped tarball containing every non-excluded component
+// under target, plus a manifest.json, to w.
+func Create(target string, w io.Writer, toolVersion string, opts Options) (Manifest, error) {
+	files, err := collectFiles(target, opts)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest, err := buildManifest(target, toolVersion, files)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := writeTarEntry(tw, ManifestFileName, manifestData); err != nil {
+		return Manifest{}, err
+	}
+
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(target, rel)) //nolint:gosec // Path is derived from the backup target
+		if err != nil {
+			return Manifest{}, err
+		}
+		if err := writeTarEntry(tw, rel, data); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	return manifest, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// CreateDir writes every non-excluded component under target, plus a
+// manifest.json, as plain files under destDir.
+func CreateDir(target, destDir, toolVersion string, opts Options) (Manifest, error) {
+	files, err := collectFiles(target, opts)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest, err := buildManifest(target, toolVersion, files)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return Manifest{}, err
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := os.WriteFile(filepath.Join(destDir, ManifestFileName), manifestData, 0644); err != nil {
+		return Manifest{}, err
+	}
+
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(target, rel)) //nolint:gosec // Path is derived from the backup target
+		if err != nil {
+			return Manifest{}, err
+		}
+		dest := filepath.Join(destDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return Manifest{}, err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	return manifest, nil
+}
+
+// Restore replays a backup created by Create or CreateDir back onto target.
+// src may be a directory produced by CreateDir, a tarball path produced by
+// Create, or "-" to read a tarball from stdin.
+func Restore(src, target string, force bool, opts Options) error {
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		return restoreFromDir(src, target, force, opts)
+	}
+
+	if src == "-" {
+		return restoreFromTar(os.Stdin, target, force, opts)
+	}
+
+	f, err := os.Open(src) //nolint:gosec // Path is supplied explicitly by the caller (CLI argument)
+	if err != nil {
+		return fmt.Errorf("failed to open backup %s: %w", src, err)
+	}
+	defer f.Close()
+	return restoreFromTar(f, target, force, opts)
+}
+
+func restoreFromDir(dir, target string, force bool, opts Options) error {
+	manifestData, err := os.ReadFile(filepath.Join(dir, ManifestFileName)) //nolint:gosec // Path is derived from the backup source
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ManifestFileName, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+
+	files := make(map[string][]byte, len(manifest.Files))
+	for _, entry := range manifest.Files {
+		if err := safeRelPath(entry.Path); err != nil {
+			return fmt.Errorf("refusing to restore %s: %w", ManifestFileName, err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Path)) //nolint:gosec // Path is validated by safeRelPath above
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Path, err)
+		}
+		files[entry.Path] = data
+	}
+
+	return restoreFiles(manifest, files, target, force, opts)
+}
+
+func restoreFromTar(r io.Reader, target string, force bool, opts Options) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	var manifestData []byte
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from backup archive: %w", hdr.Name, err)
+		}
+
+		if hdr.Name == ManifestFileName {
+			manifestData = data
+			continue
+		}
+		if err := safeRelPath(hdr.Name); err != nil {
+			return fmt.Errorf("refusing to restore backup archive: %w", err)
+		}
+		files[hdr.Name] = data
+	}
+
+	if manifestData == nil {
+		return fmt.Errorf("backup archive is missing %s", ManifestFileName)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+
+	return restoreFiles(manifest, files, target, force, opts)
+}
+
+// restoreFiles writes each file recorded in manifest back under target,
+// verifying its content against the recorded SHA-256, skipping excluded
+// components, and merging settings.json rather than clobbering it.
+func restoreFiles(manifest Manifest, files map[string][]byte, target string, force bool, opts Options) error {
+	settingsPath := componentPaths[ComponentSettings]
+
+	for _, entry := range manifest.Files {
+		if err := safeRelPath(entry.Path); err != nil {
+			return fmt.Errorf("refusing to restore %s: %w", ManifestFileName, err)
+		}
+
+		component := componentForPath(entry.Path)
+		if component != "" && opts.excludes(component) {
+			continue
+		}
+
+		data, ok := files[entry.Path]
+		if !ok {
+			return fmt.Errorf("backup is missing recorded file %s", entry.Path)
+		}
+
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != entry.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s, backup may be corrupt", entry.Path)
+		}
+
+		dest := filepath.Join(target, entry.Path)
+
+		if entry.Path == settingsPath {
+			if err := restoreSettings(dest, data, force); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+			}
+			continue
+		}
+
+		if _, err := os.Stat(dest); err == nil && !force {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// restoreSettings writes newData to dest, merging it into any existing
+// settings.json with config.Merge (the backup's values overlay the existing
+// ones) rather than clobbering it, mirroring setup.Manager.mergeSettings.
+// The merge is two-way: no common ancestor version is consulted.
+func restoreSettings(dest string, newData []byte, force bool) error {
+	newSettings, err := config.LoadJSON(newData)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	existingData, err := os.ReadFile(dest) //nolint:gosec // Path is derived from the restore target
+	if os.IsNotExist(err) || force {
+		data, err := config.SaveJSON(newSettings)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dest, append(data, '\n'), 0644)
+	}
+	if err != nil {
+		return err
+	}
+
+	existingSettings, err := config.LoadJSON(existingData)
+	if err != nil {
+		return err
+	}
+
+	merged := config.Merge(existingSettings, newSettings)
+	data, err := config.SaveJSON(merged)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, append(data, '\n'), 0644)
+}
+
+// safeRelPath rejects a manifest or tar entry path that would escape the
+// restore target when joined onto it (filepath.Join(target, rel)) -- an
+// absolute path, or a relative path whose cleaned form starts with "..".
+// Backups are data from outside agentctl's control (a tarball, a directory,
+// or stdin), so a crafted manifest.json entry like "../../.ssh/authorized_keys"
+// must never be allowed to write (or read, for restoreFromDir) outside the
+// intended directory.
+func safeRelPath(rel string) error {
+	if rel == "" {
+		return fmt.Errorf("empty path in backup")
+	}
+	if filepath.IsAbs(rel) {
+		return fmt.Errorf("unsafe path %q: absolute paths are not allowed", rel)
+	}
+	cleaned := filepath.Clean(rel)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("unsafe path %q: escapes the restore target", rel)
+	}
+	return nil
+}
+
+func componentForPath(relPath string) string {
+	for component, p := range componentPaths {
+		if relPath == p || strings.HasPrefix(relPath, p+string(filepath.Separator)) {
+			return component
+		}
+	}
+	return ""
+}
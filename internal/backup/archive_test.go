@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// maliciousTarball builds a gzipped tar stream whose manifest.json records an
+// escaping path, with a matching tar entry carrying the same escaping name,
+// mimicking what a crafted backup archive would contain.
+func maliciousTarball(t *testing.T, escapingPath string) []byte {
+	t.Helper()
+
+	content := []byte("pwned")
+	sum := sha256.Sum256(content)
+	manifest := Manifest{
+		ToolVersion: "test",
+		CreatedAt:   time.Now().UTC(),
+		Files:       []FileEntry{{Path: escapingPath, SHA256: hex.EncodeToString(sum[:])}},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := writeTarEntry(tw, ManifestFileName, manifestData); err != nil {
+		t.Fatalf("failed to write manifest entry: %v", err)
+	}
+	if err := writeTarEntry(tw, escapingPath, content); err != nil {
+		t.Fatalf("failed to write escaping entry: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRestoreFromTarRejectsPathTraversalEntry(t *testing.T) {
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+
+	escapeTarget := filepath.Join(outside, "escaped.txt")
+	rel, err := filepath.Rel(target, escapeTarget)
+	if err != nil {
+		t.Fatalf("failed to compute relative escape path: %v", err)
+	}
+
+	data := maliciousTarball(t, rel)
+
+	if err := restoreFromTar(bytes.NewReader(data), target, true, Options{}); err == nil {
+		t.Fatal("expected restoreFromTar to reject a path-traversal entry")
+	}
+
+	if _, err := os.Stat(escapeTarget); !os.IsNotExist(err) {
+		t.Fatalf("expected %s not to exist, got err=%v", escapeTarget, err)
+	}
+}
+
+func TestRestoreFromTarRejectsAbsolutePathEntry(t *testing.T) {
+	target := t.TempDir()
+	escapeTarget := filepath.Join(t.TempDir(), "escaped.txt")
+
+	data := maliciousTarball(t, escapeTarget)
+
+	if err := restoreFromTar(bytes.NewReader(data), target, true, Options{}); err == nil {
+		t.Fatal("expected restoreFromTar to reject an absolute-path entry")
+	}
+	if _, err := os.Stat(escapeTarget); !os.IsNotExist(err) {
+		t.Fatalf("expected %s not to exist, got err=%v", escapeTarget, err)
+	}
+}
+
+func TestRestoreFromDirRejectsPathTraversalManifestEntry(t *testing.T) {
+	outside := t.TempDir()
+	srcDir := filepath.Join(outside, "src")
+	target := filepath.Join(outside, "target")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatalf("failed to create src dir: %v", err)
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+
+	escapeTarget := filepath.Join(outside, "escaped.txt")
+	rel, err := filepath.Rel(target, escapeTarget)
+	if err != nil {
+		t.Fatalf("failed to compute relative escape path: %v", err)
+	}
+
+	content := []byte("pwned")
+	sum := sha256.Sum256(content)
+	manifest := Manifest{
+		ToolVersion: "test",
+		CreatedAt:   time.Now().UTC(),
+		Files:       []FileEntry{{Path: rel, SHA256: hex.EncodeToString(sum[:])}},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, ManifestFileName), manifestData, 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	// The escaping file needs to actually exist relative to srcDir for
+	// restoreFromDir's read to have any chance of succeeding absent the fix;
+	// write it so the test proves the path check itself blocks the escape,
+	// not just a missing file.
+	escapingSrcPath := filepath.Join(srcDir, rel)
+	if err := os.MkdirAll(filepath.Dir(escapingSrcPath), 0755); err != nil {
+		t.Fatalf("failed to create escaping src parent dir: %v", err)
+	}
+	if err := os.WriteFile(escapingSrcPath, content, 0644); err != nil {
+		t.Fatalf("failed to write escaping src file: %v", err)
+	}
+
+	if err := restoreFromDir(srcDir, target, true, Options{}); err == nil {
+		t.Fatal("expected restoreFromDir to reject a path-traversal manifest entry")
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		t.Fatalf("failed to read target dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected nothing written under target, found %v", entries)
+	}
+}
+
+func TestSafeRelPathAcceptsOrdinaryRelativePaths(t *testing.T) {
+	for _, p := range []string{"CLAUDE.md", filepath.Join(".claude", "settings.json"), filepath.Join("a", "b", "c.txt")} {
+		if err := safeRelPath(p); err != nil {
+			t.Errorf("expected %q to be accepted, got %v", p, err)
+		}
+	}
+}
+
+func TestSafeRelPathRejectsEscapingOrAbsolutePaths(t *testing.T) {
+	for _, p := range []string{"../escaped.txt", "../../etc/passwd", "/etc/passwd", filepath.Join("a", "..", "..", "b"), ""} {
+		if err := safeRelPath(p); err == nil {
+			t.Errorf("expected %q to be rejected", p)
+		}
+	}
+}
+
+func TestRestoreFromTarAcceptsWellFormedBackup(t *testing.T) {
+	target := t.TempDir()
+
+	content := []byte("# hello")
+	sum := sha256.Sum256(content)
+	manifest := Manifest{
+		ToolVersion: "test",
+		CreatedAt:   time.Now().UTC(),
+		Files:       []FileEntry{{Path: "CLAUDE.md", SHA256: hex.EncodeToString(sum[:])}},
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := writeTarEntry(tw, ManifestFileName, manifestData); err != nil {
+		t.Fatalf("failed to write manifest entry: %v", err)
+	}
+	if err := writeTarEntry(tw, "CLAUDE.md", content); err != nil {
+		t.Fatalf("failed to write CLAUDE.md entry: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	if err := restoreFromTar(bytes.NewReader(buf.Bytes()), target, true, Options{}); err != nil {
+		t.Fatalf("restoreFromTar failed on a well-formed backup: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(target, "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("failed to read restored file: %v", err)
+	}
+	if string(got) != "# hello" {
+		t.Errorf("expected restored content '# hello', got %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Package backup snapshots and restores the Claude Code configuration state
+// that setup.Manager.Install produces: CLAUDE.md, .claude/agents,
+// .claude/skills, .claude/settings.json, .mcp.json, and workspace metadata.
+// A backup is either a gzipped tarball or a plain directory, always
+// containing a manifest.json recording tool version, git HEAD, a timestamp,
+// and a SHA-256 per file.
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// ManifestFileName is the name of the manifest recorded alongside every
+// backup's files.
+const ManifestFileName = "manifest.json"
+
+// Component names accepted by the --exclude flag.
+const (
+	ComponentClaudeMD   = "claude-md"
+	ComponentAgents     = "agents"
+	ComponentSkills     = "skills"
+	ComponentSettings   = "settings"
+	ComponentMCP        = "mcp"
+	ComponentWorkspaces = "workspaces"
+)
+
+// componentPaths maps each component to the path it occupies under a repo,
+// matching what setup.Manager.Install produces.
+var componentPaths = map[string]string{
+	ComponentClaudeMD:   "CLAUDE.md",
+	ComponentAgents:     filepath.Join(".claude", "agents"),
+	ComponentSkills:     filepath.Join(".claude", "skills"),
+	ComponentSettings:   filepath.Join(".claude", "settings.json"),
+	ComponentMCP:        ".mcp.json",
+	ComponentWorkspaces: filepath.Join(".git", "agentctl", "workspace-meta.json"),
+}
+
+// FileEntry records one backed-up file's path (relative to the repo root)
+// and content hash.
+type FileEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest describes a backup's contents and provenance.
+type Manifest struct {
+	ToolVersion string      `json:"tool_version"`
+	GitHead     string      `json:"git_head,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Files       []FileEntry `json:"files"`
+}
+
+// Options controls which components a backup or restore operation includes.
+type Options struct {
+	Exclude []string
+}
+
+func (o Options) excludes(component string) bool {
+	for _, e := range o.Exclude {
+		if e == component {
+			return true
+		}
+	}
+	return false
+}
+
+// collectFiles walks every non-excluded component under target and returns
+// their paths relative to target, in a stable order.
+func collectFiles(target string, opts Options) ([]string, error) {
+	var files []string
+	for component, relPath := range componentPaths {
+		if opts.excludes(component) {
+			continue
+		}
+
+		absPath := filepath.Join(target, relPath)
+		info, err := os.Stat(absPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, relPath)
+			continue
+		}
+
+		err = filepath.Walk(absPath, func(p string, i os.FileInfo, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if i.IsDir() {
+				return nil
+			}
+			rel, relErr := filepath.Rel(target, p)
+			if relErr != nil {
+				return relErr
+			}
+			files = append(files, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is derived from the backup target
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildManifest hashes every file in files (relative to target) and records
+// the current git HEAD and tool version.
+func buildManifest(target, toolVersion string, files []string) (Manifest, error) {
+	m := Manifest{
+		ToolVersion: toolVersion,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if repo, err := git.OpenRepoWithDiscover(context.Background(), target); err == nil {
+		if head, err := repo.Head(); err == nil {
+			m.GitHead = head.Hash().String()
+		}
+	}
+
+	for _, rel := range files {
+		sum, err := hashFile(filepath.Join(target, rel))
+		if err != nil {
+			return Manifest{}, err
+		}
+		m.Files = append(m.Files, FileEntry{Path: rel, SHA256: sum})
+	}
+
+	return m, nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/backup"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewBackupCmd creates the backup command.
+func NewBackupCmd() *cobra.Command {
+	var stdout, asDir bool
+	var exclude []string
+
+	cmd := &cobra.Command{
+		Use:   "backup [path]",
+		Short: "Back up CLAUDE.md, agents, skills, settings, MCP config, and workspace metadata",
+		Long:  "Snapshots everything `agentctl init` installs — CLAUDE.md, .claude/agents, .claude/skills, .claude/settings.json, .mcp.json, and workspace metadata — into a gzipped tarball (or a directory with --dir), recording a manifest.json with tool version, git HEAD, timestamp, and a SHA-256 per file.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			opts := backup.Options{Exclude: exclude}
+
+			if stdout {
+				if _, err := backup.Create(target, os.Stdout, versionInfo.version, opts); err != nil {
+					output.Error(err)
+					return err
+				}
+				return nil
+			}
+
+			dest := defaultBackupPath(asDir)
+			if len(args) > 0 {
+				dest = args[0]
+			}
+
+			var manifest backup.Manifest
+			if asDir {
+				manifest, err = backup.CreateDir(target, dest, versionInfo.version, opts)
+				if err != nil {
+					output.Error(err)
+					return err
+				}
+			} else {
+				f, err := os.Create(dest) //nolint:gosec // Path is supplied explicitly by the caller (CLI argument)
+				if err != nil {
+					output.Error(err)
+					return err
+				}
+				manifest, err = backup.Create(target, f, versionInfo.version, opts)
+				closeErr := f.Close()
+				if err != nil {
+					output.Error(err)
+					return err
+				}
+				if closeErr != nil {
+					output.Error(closeErr)
+					return closeErr
+				}
+			}
+
+			fmt.Printf("Backed up %d file(s) to %s\n", len(manifest.Files), dest)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&stdout, "stdout", false, "Write the tarball to stdout instead of a file")
+	cmd.Flags().BoolVar(&asDir, "dir", false, "Write the backup as a directory instead of a gzipped tarball")
+	cmd.Flags().StringArrayVar(&exclude, "exclude", nil, "Component to exclude: claude-md, agents, skills, settings, mcp, workspaces (repeatable)")
+
+	return cmd
+}
+
+func defaultBackupPath(asDir bool) string {
+	name := fmt.Sprintf("agentctl-backup-%s", time.Now().UTC().Format("20060102-150405"))
+	if asDir {
+		return name
+	}
+	return name + ".tar.gz"
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/remote"
+	"github.com/spf13/cobra"
+)
+
+// NewBridgeCmd creates the bridge command group.
+func NewBridgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge",
+		Short: "Configure self-hosted forge bridges for PR integration",
+		Long:  "Registers self-hosted GitHub Enterprise, GitLab, and Gitea/Forgejo hosts so `workspace pr` and `update --pr` can detect the right provider for origin remotes that aren't github.com or gitlab.com.",
+	}
+
+	cmd.AddCommand(newBridgeConfigureCmd(), newBridgeListCmd())
+
+	return cmd
+}
+
+func newBridgeConfigureCmd() *cobra.Command {
+	var provider string
+
+	cmd := &cobra.Command{
+		Use:   "configure <host>",
+		Short: "Map a self-hosted forge host to a provider",
+		Long:  "Records that origin remotes on host should be treated as provider (github, gitlab, or gitea) when auto-detecting where to open/query pull requests.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			host := args[0]
+
+			switch provider {
+			case "github", "gitlab", "gitea":
+			default:
+				err := fmt.Errorf("unknown provider %q (must be github, gitlab, or gitea)", provider)
+				output.Error(err)
+				return err
+			}
+
+			target, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			cfg, err := remote.LoadBridgeConfig(target)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+			cfg.Hosts[host] = provider
+
+			if err := remote.SaveBridgeConfig(target, cfg); err != nil {
+				output.Error(err)
+				return err
+			}
+
+			fmt.Printf("Mapped %s to %s\n", host, provider)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "Provider kind for this host (github|gitlab|gitea)")
+	_ = cmd.MarkFlagRequired("provider")
+
+	return cmd
+}
+
+func newBridgeListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured forge bridges",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			target, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			cfg, err := remote.LoadBridgeConfig(target)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if len(cfg.Hosts) == 0 {
+				fmt.Println("No forge bridges configured.")
+				return nil
+			}
+			for host, provider := range cfg.Hosts {
+				fmt.Printf("  %s -> %s\n", host, provider)
+			}
+			return nil
+		},
+	}
+}
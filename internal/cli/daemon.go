@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/daemon"
+	"github.com/spf13/cobra"
+)
+
+// NewDaemonCmd creates the daemon command group.
+func NewDaemonCmd() *cobra.Command {
+	return daemon.NewDaemonCmd()
+}
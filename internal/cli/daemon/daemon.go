@@ -0,0 +1,28 @@
+// Package daemon provides the `agentctl daemon` command group for
+// installing and managing the background scheduled-hook runner.
+package daemon
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewDaemonCmd creates the daemon command group.
+func NewDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Manage the background scheduled-hook runner",
+		Long:  "Installs and controls the long-lived process that fires `agentctl hook schedule` entries on their cron triggers.",
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		newDaemonInstallCmd(),
+		newDaemonRunCmd(),
+		newDaemonStatusCmd(),
+		newDaemonLogsCmd(),
+		newDaemonRestartCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/daemon"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install and start the background scheduled-hook runner",
+		Long:  "Generates a launchd plist on macOS or a systemd user unit on Linux that runs `agentctl daemon run` at login.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			path, err := daemon.Install()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"unit_path": path}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Printf("Installed and started agentctl daemon (%s)\n", path)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryantking/agentctl/internal/daemon"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print the daemon's log file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			path, err := daemon.LogPath()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the user's home directory
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{"path": path, "contents": string(data)})
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+
+	return cmd
+}
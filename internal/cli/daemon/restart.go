@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/daemon"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonRestartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restart the background daemon",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			if err := daemon.Restart(); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"restarted": true}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Println("Restarted agentctl daemon")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,19 @@
+package daemon
+
+import (
+	"github.com/ryantking/agentctl/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the scheduled-hook poll loop in the foreground",
+		Long:  "Blocks forever, firing due schedules every minute. This is what the installed launchd/systemd unit executes; run it directly for debugging.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return daemon.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
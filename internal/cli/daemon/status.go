@@ -0,0 +1,37 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/daemon"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the background daemon's service status",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			status, err := daemon.Status()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"status": status}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Println(status)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -17,7 +17,7 @@ func NewHookContextInfoCmd() *cobra.Command {
 the conversation before Claude processes the user's message.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Consume stdin if present
-			_, _ = hook.GetStdinData()
+			_, _ = hook.GetStdinData(cmd.Context())
 
 			context, err := hook.ContextInfo()
 			if err != nil {
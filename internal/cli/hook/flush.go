@@ -0,0 +1,51 @@
+package hook
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+// NewHookFlushCmd creates the hook flush command.
+func NewHookFlushCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "SessionEnd/Stop hook - coalesces queued changes into a single commit",
+		Long: `Flushes the pending change queue for the current branch (written by
+PostEdit/PostWrite when AGENTCTL_HOOK_MODE is "debounce" or "session") into
+a single commit. Intended to run from a SessionEnd or Stop hook.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Consume stdin if present
+			_, _ = hook.GetStdinData(cmd.Context())
+
+			repoRoot, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				os.Exit(0)
+				return nil
+			}
+
+			branch, err := git.GetCurrentBranch(repoRoot)
+			if err != nil || branch == "" {
+				os.Exit(0)
+				return nil
+			}
+
+			msg, err := hook.FlushPending(repoRoot, branch)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error flushing pending changes: %v\n", err)
+				os.Exit(0)
+				return nil
+			}
+			if msg != "" {
+				fmt.Println(msg)
+			}
+			os.Exit(0)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -2,6 +2,7 @@
 package hook
 
 import (
+	"github.com/ryantking/agentctl/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -13,14 +14,33 @@ func NewHookCmd() *cobra.Command {
 		Long:  "Hook commands are designed to be called directly from Claude Code hooks. They handle stdin parsing, error handling, and exit codes appropriately for use as hook commands.",
 	}
 
-	cmd.AddCommand(
+	subcommands := []*cobra.Command{
 		NewHookPostEditCmd(),
 		NewHookPostWriteCmd(),
 		NewHookInjectContextCmd(),
 		NewHookNotifyInputCmd(),
 		NewHookNotifyStopCmd(),
 		NewHookNotifyErrorCmd(),
-	)
+		NewHookNotifySlackCmd(),
+		NewHookFlushCmd(),
+		NewHookScheduleCmd(),
+		NewHookSummarizeCmd(),
+		NewHookPreCommitCmd(),
+		NewHookReportPanicCmd(),
+	}
+
+	// Wrap every hook's RunE so a panic is captured (with stack, hook name,
+	// and stdin payload) and reported to the configured errorReporting
+	// sink instead of crashing with an unpredictable exit code. Commands
+	// that already handle their own errors via os.Exit are unaffected:
+	// Wrap only ever sees a panic or a normally-returned error.
+	for _, sub := range subcommands {
+		if sub.RunE != nil {
+			sub.RunE = report.Wrap(sub.Name(), sub.RunE)
+		}
+	}
+
+	cmd.AddCommand(subcommands...)
 
 	return cmd
 }
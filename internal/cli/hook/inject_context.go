@@ -15,9 +15,9 @@ func NewHookInjectContextCmd() *cobra.Command {
 		Short: "UserPromptSubmit hook - injects live context into each user prompt",
 		Long: `Outputs context information that gets automatically injected into
 the conversation before Claude processes the user's message.`,
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Consume stdin if present
-			_, _ = hook.GetStdinData()
+			_, _ = hook.GetStdinData(cmd.Context())
 
 			context, err := hook.ContextInfo()
 			if err != nil {
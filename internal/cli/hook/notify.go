@@ -13,10 +13,10 @@ func NewHookNotifyInputCmd() *cobra.Command {
 		Use:   "notify-input [message]",
 		Short: "Notification hook - sends notification when input is needed",
 		Args:  cobra.MaximumNArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
-			input, _ := hook.GetStdinData()
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, _ := hook.GetStdinData(cmd.Context())
 			message := ""
-			
+
 			// Prefer message from stdin (hook input)
 			if input != nil && input.Message != "" {
 				message = input.Message
@@ -24,7 +24,7 @@ func NewHookNotifyInputCmd() *cobra.Command {
 				// Fall back to command-line argument
 				message = args[0]
 			}
-			
+
 			_ = hook.NotifyInput(message)
 			os.Exit(0)
 			return nil
@@ -39,8 +39,8 @@ func NewHookNotifyStopCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "notify-stop",
 		Short: "Stop hook - sends notification when a task completes",
-		RunE: func(_ *cobra.Command, _ []string) error {
-			input, _ := hook.GetStdinData()
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			input, _ := hook.GetStdinData(cmd.Context())
 			transcriptPath := ""
 			if input != nil {
 				transcriptPath = hook.GetTranscriptPath(input)
@@ -60,10 +60,10 @@ func NewHookNotifyErrorCmd() *cobra.Command {
 		Use:   "notify-error [message]",
 		Short: "Send error notification",
 		Args:  cobra.MaximumNArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
-			input, _ := hook.GetStdinData()
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, _ := hook.GetStdinData(cmd.Context())
 			message := ""
-			
+
 			// Prefer message from stdin (hook input)
 			if input != nil && input.Message != "" {
 				message = input.Message
@@ -71,7 +71,7 @@ func NewHookNotifyErrorCmd() *cobra.Command {
 				// Fall back to command-line argument
 				message = args[0]
 			}
-			
+
 			_ = hook.NotifyError(message)
 			os.Exit(0)
 			return nil
@@ -80,4 +80,3 @@ func NewHookNotifyErrorCmd() *cobra.Command {
 
 	return cmd
 }
-
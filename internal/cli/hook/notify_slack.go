@@ -0,0 +1,47 @@
+package hook
+
+import (
+	"os"
+
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+// NewHookNotifySlackCmd creates the hook notify-slack command.
+func NewHookNotifySlackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify-slack [message]",
+		Short: "Posts a Claude Code notification to Slack as a Block Kit message",
+		Long: `Reads the same stdin schema as notify-input/notify-stop/notify-error and
+posts it to the webhook configured under settings.json's
+"notifications.slack" block, using rich_text blocks (bold tool name, a
+linked file path, and a preformatted code block for tool output) instead
+of a plain-text message. A no-op when notifications.slack.webhookUrl isn't
+configured.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, _ := hook.GetStdinData(cmd.Context())
+
+			message := ""
+			if input != nil && input.Message != "" {
+				message = input.Message
+			} else if len(args) > 0 {
+				message = args[0]
+			}
+
+			var sessionID, toolName, filePath, output string
+			if input != nil {
+				sessionID = input.SessionID
+				toolName = input.ToolName
+				filePath = hook.GetFilePath(input)
+				output = hook.GetToolOutput(input)
+			}
+
+			_ = hook.NotifySlack(cmd.Context(), sessionID, toolName, filePath, message, output)
+			os.Exit(0)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -14,7 +14,7 @@ func NewHookPostEditCmd() *cobra.Command {
 		Short: "PostToolUse hook for Edit tool",
 		Long:  "Auto-commits changes if on a feature branch. Reads file path and session ID from stdin JSON.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			input, _ := hook.GetStdinData()
+			input, _ := hook.GetStdinData(cmd.Context())
 			filePath := hook.GetFilePath(input)
 			_ = hook.PostEdit(filePath)
 			os.Exit(0)
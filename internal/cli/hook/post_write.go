@@ -14,7 +14,7 @@ func NewHookPostWriteCmd() *cobra.Command {
 		Short: "PostToolUse hook for Write tool (new files)",
 		Long:  "Auto-commits new files if on a feature branch. Reads file path and session ID from stdin JSON.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			input, _ := hook.GetStdinData()
+			input, _ := hook.GetStdinData(cmd.Context())
 			filePath := hook.GetFilePath(input)
 			_ = hook.PostWrite(filePath)
 			os.Exit(0)
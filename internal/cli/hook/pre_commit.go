@@ -0,0 +1,40 @@
+package hook
+
+import (
+	"os"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+// NewHookPreCommitCmd creates the hook pre-commit command.
+func NewHookPreCommitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pre-commit",
+		Short: "Checks the current worktree for unmerged paths before an auto-commit",
+		Long: `Intended for use as a PreToolUse hook. Exits non-zero (and fires an
+error notification) if the worktree has unmerged paths from a conflicted
+merge or rebase, so the auto-commit hooks don't commit over a conflict.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Consume stdin if present
+			_, _ = hook.GetStdinData(cmd.Context())
+
+			repoRoot, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				os.Exit(0)
+				return nil
+			}
+
+			if err := hook.PreCommit(repoRoot); err != nil {
+				os.Exit(1)
+				return nil
+			}
+
+			os.Exit(0)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+package hook
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/ryantking/agentctl/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// NewHookReportPanicCmd creates the hook report-panic command.
+func NewHookReportPanicCmd() *cobra.Command {
+	var sink, target, errMsg string
+
+	cmd := &cobra.Command{
+		Use:   "report-panic",
+		Short: "Manually reports a hook failure to the configured error reporting sink",
+		Long: `Sends a single report.Entry to the sink configured under settings.json's
+"errorReporting" block, for a caller (e.g. a wrapper shell script around a
+hook Claude Code doesn't invoke through agentctl directly) that caught a
+failure itself and wants it to show up alongside failures report.Wrap
+captures automatically.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Consume stdin if present
+			_, _ = hook.GetStdinData(cmd.Context())
+
+			if errMsg == "" {
+				errMsg = "reported without an --error message"
+			}
+
+			cfg := report.LoadConfig(cmd.Context())
+			if sink != "" {
+				cfg.SinkName = sink
+			}
+			if target != "" {
+				cfg.Target = target
+			}
+
+			if err := report.Capture(cmd.Context(), cfg, report.Entry{
+				Hook:  "report-panic",
+				Error: errMsg,
+			}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reporting failure: %v\n", err)
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sink, "sink", "", "Override the configured sink (file, http, sentry)")
+	cmd.Flags().StringVar(&target, "target", "", "Override the configured sink target (path, URL, or DSN)")
+	cmd.Flags().StringVar(&errMsg, "error", "", "The error message to report")
+
+	return cmd
+}
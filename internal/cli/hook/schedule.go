@@ -0,0 +1,240 @@
+package hook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/scheduler"
+	"github.com/spf13/cobra"
+)
+
+// NewHookScheduleCmd creates the hook schedule command group, for running
+// registered hooks on cron-style triggers via `agentctl daemon run`.
+func NewHookScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage cron-triggered hook schedules",
+		Long:  "Schedules are persisted in ~/.config/agentctl/schedules.yaml and run by `agentctl daemon run`.",
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		newScheduleAddCmd(),
+		newScheduleListCmd(),
+		newScheduleRemoveCmd(),
+		newScheduleRunCmd(),
+	)
+
+	return cmd
+}
+
+func newScheduleAddCmd() *cobra.Command {
+	var hookName, cron, argsFlag, workspaceFilter string
+
+	cmd := &cobra.Command{
+		Use:   "add <id>",
+		Short: "Register a new hook schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			id := args[0]
+
+			if hookName == "" || cron == "" {
+				err := fmt.Errorf("--hook and --cron are required")
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if _, err := scheduler.Due(cron, time.Time{}, time.Now()); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			store, err := scheduler.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if _, exists := store.Find(id); exists {
+				err := fmt.Errorf("schedule %s already exists", id)
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			var schedArgs []string
+			if argsFlag != "" {
+				schedArgs = strings.Split(argsFlag, ",")
+			}
+
+			sched := scheduler.Schedule{
+				ID:              id,
+				Cron:            cron,
+				Hook:            hookName,
+				Args:            schedArgs,
+				WorkspaceFilter: workspaceFilter,
+			}
+			store.Schedules = append(store.Schedules, sched)
+
+			if err := scheduler.Save(store); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(sched)
+			}
+			fmt.Printf("Added schedule %s: %s on %q\n", id, hookName, cron)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&hookName, "hook", "", "Hook command to run, e.g. \"context-info\" or \"notify-stop\"")
+	cmd.Flags().StringVar(&cron, "cron", "", "5-field cron expression, e.g. \"*/15 * * * *\"")
+	cmd.Flags().StringVar(&argsFlag, "args", "", "Comma-separated extra arguments to pass to the hook")
+	cmd.Flags().StringVar(&workspaceFilter, "workspace-filter", "", "Run once per managed workspace matching \"all\", \"clean\", or \"dirty\"")
+
+	return cmd
+}
+
+func newScheduleListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registered hook schedules",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			store, err := scheduler.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.WriteJSON(store.Schedules)
+			}
+
+			if len(store.Schedules) == 0 {
+				fmt.Print("\n  No schedules registered.\n\n  Add one with: agentctl hook schedule add <id> --hook <name> --cron \"<expr>\"\n\n")
+				return nil
+			}
+
+			for _, sched := range store.Schedules {
+				fmt.Printf("%-20s %-20s %s\n", sched.ID, sched.Cron, sched.Hook)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <id>",
+		Short: "Remove a registered hook schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			id := args[0]
+
+			store, err := scheduler.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if !store.Remove(id) {
+				err := fmt.Errorf("no such schedule: %s", id)
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := scheduler.Save(store); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"id": id, "removed": true}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Printf("Removed schedule %s\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run every schedule that's currently due",
+		Long:  "Intended to be polled by `agentctl daemon run`; can also be invoked manually to force a check.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			store, err := scheduler.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			ran, err := scheduler.RunDue(cmd.Context(), store, time.Now())
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"ran": ran}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			if len(ran) == 0 {
+				fmt.Println("No schedules were due")
+			} else {
+				fmt.Printf("Ran schedules: %s\n", strings.Join(ran, ", "))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package hook
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/transcript"
+	"github.com/spf13/cobra"
+)
+
+// NewHookSummarizeCmd creates the hook summarize command.
+func NewHookSummarizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summarize <transcript>",
+		Short: "Summarize a transcript JSONL file",
+		Long:  "Parses a Claude Code transcript and prints the final assistant text, tools used, last error, and token/duration totals. Intended for scripting; the same summary feeds notify-stop's notification body.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer func() { _ = file.Close() }()
+
+			summary, err := transcript.Summarize(file)
+			if err != nil {
+				return err
+			}
+
+			return output.CurrentWriter().Emit(summary, func(w io.Writer) {
+				printSummary(w, summary)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func printSummary(w io.Writer, summary *transcript.Summary) {
+	fmt.Fprintf(w, "Final text:   %s\n", summary.FinalText)
+	if summary.LastError != "" {
+		fmt.Fprintf(w, "Last error:   %s\n", summary.LastError)
+	}
+	fmt.Fprintf(w, "Tools used:   %s\n", strings.Join(summary.ToolsUsed, ", "))
+	fmt.Fprintf(w, "Tokens:       %d in / %d out\n", summary.TokensIn, summary.TokensOut)
+	fmt.Fprintf(w, "Duration:     %dms\n", summary.DurationMS)
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/inbox"
+	"github.com/spf13/cobra"
+)
+
+// NewInboxCmd creates the inbox command group.
+func NewInboxCmd() *cobra.Command {
+	return inbox.NewInboxCmd()
+}
@@ -0,0 +1,28 @@
+// Package inbox provides the `agentctl inbox` command group for browsing
+// and acting on cached remote notifications.
+package inbox
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewInboxCmd creates the inbox command group.
+func NewInboxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inbox",
+		Short: "Browse and manage remote notifications",
+		Long:  "Commands for syncing and browsing pull request, issue, and CI notifications from the repository's remote provider.",
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		NewInboxListCmd(),
+		NewInboxReadCmd(),
+		NewInboxUnreadCmd(),
+		NewInboxPinCmd(),
+		NewInboxMarkReadCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,90 @@
+package inbox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/inbox"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewInboxListCmd creates the inbox list command.
+func NewInboxListCmd() *cobra.Command {
+	var all, unread, pinned, pick bool
+	var types string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List cached notifications",
+		Long:  "Syncs the repository's remote notifications and lists them. Defaults to unread items; use --all to include read ones.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			items, err := inbox.Sync(cmd.Context(), repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			filter := inbox.Filter{Unread: !all, Pinned: pinned}
+			if types != "" {
+				filter.Types = strings.Split(types, ",")
+			}
+			filtered := inbox.List(items, filter)
+
+			if jsonMode {
+				return output.WriteJSON(filtered)
+			}
+
+			if pick {
+				return ui.ShowInboxTable(filtered)
+			}
+
+			printItems(filtered)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include already-read notifications")
+	cmd.Flags().BoolVar(&unread, "unread", false, "Only show unread notifications (default)")
+	cmd.Flags().BoolVar(&pinned, "pinned", false, "Only show pinned notifications")
+	cmd.Flags().StringVar(&types, "type", "", "Comma-separated notification types to include (pr,issue,ci)")
+	cmd.Flags().BoolVar(&pick, "pick", false, "Open an interactive picker instead of printing a list")
+
+	return cmd
+}
+
+func printItems(items []inbox.Item) {
+	if len(items) == 0 {
+		fmt.Print("\n  No notifications found.\n\n")
+		return
+	}
+
+	for _, item := range items {
+		marker := " "
+		if item.Pinned {
+			marker = "*"
+		}
+		readIcon := "●"
+		if item.Read {
+			readIcon = " "
+		}
+		fmt.Printf("%s %s %-4d %-6s %s\n", marker, readIcon, item.ID, item.Type, item.Title)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,62 @@
+package inbox
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/inbox"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewInboxMarkReadCmd creates the inbox mark-read command.
+func NewInboxMarkReadCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "mark-read",
+		Short: "Mark notifications as read",
+		Long:  "With --all, marks every cached notification as read.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			if !all {
+				err := fmt.Errorf("mark-read requires --all (use `inbox read <id>` for a single notification)")
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			db, err := inbox.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			inbox.MarkAllRead(db)
+
+			if err := inbox.Save(db); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"marked": len(db.Items)}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Printf("Marked %d notifications as read\n", len(db.Items))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Mark every cached notification as read")
+
+	return cmd
+}
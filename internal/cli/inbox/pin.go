@@ -0,0 +1,71 @@
+package inbox
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ryantking/agentctl/internal/inbox"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewInboxPinCmd creates the inbox pin command.
+func NewInboxPinCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin <id>",
+		Short: "Toggle whether a notification is pinned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				err = fmt.Errorf("invalid notification ID: %s", args[0])
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			db, err := inbox.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := inbox.Pin(db, id); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := inbox.Save(db); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			item, _ := inbox.Find(db, id)
+			data := map[string]interface{}{"id": id, "pinned": item.Pinned}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			if item.Pinned {
+				fmt.Printf("Pinned notification %d\n", id)
+			} else {
+				fmt.Printf("Unpinned notification %d\n", id)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,66 @@
+package inbox
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ryantking/agentctl/internal/inbox"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewInboxReadCmd creates the inbox read command.
+func NewInboxReadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read <id>",
+		Short: "Mark a notification as read",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				err = fmt.Errorf("invalid notification ID: %s", args[0])
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			db, err := inbox.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := inbox.MarkRead(db, id); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := inbox.Save(db); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{"id": id, "read": true}
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+			fmt.Printf("Marked notification %d as read\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -4,16 +4,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ryantking/agentctl/internal/git"
-	"github.com/ryantking/agentctl/internal/setup"
 	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
 	"github.com/spf13/cobra"
 )
 
 // NewInitCmd creates the init command.
 func NewInitCmd() *cobra.Command {
 	var globalInstall, force, noIndex bool
+	var manifestPath string
+	var setFlags []string
 
 	cmd := &cobra.Command{
 		Use:   "init",
@@ -27,31 +30,36 @@ By default, skips existing files.`,
 			if globalInstall {
 				home, err := os.UserHomeDir()
 				if err != nil {
-					result := output.Error(fmt.Sprintf("failed to get home directory: %v", err))
-					output.Output(result)
-					return err
+					wrapped := fmt.Errorf("failed to get home directory: %w", err)
+					output.Error(wrapped)
+					return wrapped
 				}
 				target = filepath.Join(home, ".claude")
 			} else {
-				target, err = git.GetRepoRoot()
+				target, err = git.GetRepoRoot(cmd.Context())
 				if err != nil {
-					msg := fmt.Sprintf("%v\n\nRun from inside a git repository or use --global", err)
-					result := output.Error(msg)
-					output.Output(result)
-					return err
+					wrapped := fmt.Errorf("%w\n\nRun from inside a git repository or use --global", err)
+					output.Error(wrapped)
+					return wrapped
 				}
 			}
 
-			manager, err := setup.NewManager(target)
+			manager, err := setup.NewManagerWithManifest(target, manifestPath)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+			manager.SetToolVersion(versionInfo.version)
+
+			overrides, err := parseSetFlags(setFlags)
 			if err != nil {
-				result := output.Error(err.Error())
-				output.Output(result)
+				output.Error(err)
 				return err
 			}
+			manager.SetSettingOverrides(overrides)
 
-			if err := manager.Install(force, noIndex || globalInstall); err != nil {
-				result := output.Error(err.Error())
-				output.Output(result)
+			if err := manager.Install(cmd.Context(), force, noIndex || globalInstall); err != nil {
+				output.Error(err)
 				return err
 			}
 
@@ -62,6 +70,29 @@ By default, skips existing files.`,
 	cmd.Flags().BoolVarP(&globalInstall, "global", "g", false, "Install to $HOME/.claude instead of current repository")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing files")
 	cmd.Flags().BoolVar(&noIndex, "no-index", false, "Skip Claude CLI repository indexing")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Path to an agentctl.yaml manifest (defaults to the embedded manifest)")
+	cmd.Flags().StringArrayVar(&setFlags, "set", nil, "Override a settings.json value by dotted path (e.g. --set hooks.PostToolUse.0.command=...), repeatable")
 
 	return cmd
 }
+
+// parseSetFlags parses a list of "dotted.path=value" strings (as supplied
+// via repeated --set flags) into an overrides map for
+// setup.Manager.SetSettingOverrides. Values are kept as plain strings;
+// callers needing a richer type should use --manifest/SettingsOverrides
+// instead.
+func parseSetFlags(setFlags []string) (map[string]interface{}, error) {
+	if len(setFlags) == 0 {
+		return nil, nil
+	}
+
+	overrides := make(map[string]interface{}, len(setFlags))
+	for _, set := range setFlags {
+		path, value, ok := strings.Cut(set, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set %q: expected dotted.path=value", set)
+		}
+		overrides[path] = value
+	}
+	return overrides, nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/backup"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewRestoreCmd creates the restore command.
+func NewRestoreCmd() *cobra.Command {
+	var force bool
+	var exclude []string
+
+	cmd := &cobra.Command{
+		Use:   "restore <path>",
+		Short: "Restore a backup created by `agentctl backup`",
+		Long:  "Replays a backup tarball or directory back onto the current repository. settings.json is two-way merged into any existing settings (the backup's values win where both set a key) rather than clobbered; pass --force to overwrite everything instead. Pass \"-\" to read a tarball from stdin.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if err := backup.Restore(args[0], target, force, backup.Options{Exclude: exclude}); err != nil {
+				output.Error(err)
+				return err
+			}
+
+			fmt.Printf("Restored backup into %s\n", target)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing files instead of merging/skipping")
+	cmd.Flags().StringArrayVar(&exclude, "exclude", nil, "Component to exclude: claude-md, agents, skills, settings, mcp, workspaces (repeatable)")
+
+	return cmd
+}
@@ -1,6 +1,10 @@
 package cli
 
 import (
+	"context"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -11,18 +15,52 @@ func Execute() error {
 
 // NewRootCmd creates the root command.
 func NewRootCmd() *cobra.Command {
+	var timeout time.Duration
+	var timeoutCancel context.CancelFunc
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "agentctl",
 		Short: "A CLI tool for managing Claude Code configurations, hooks, and isolated workspaces using git worktrees",
 		Long:  "A CLI tool for managing Claude Code configurations, hooks, and isolated workspaces using git worktrees.",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := output.ValidateFormat(format); err != nil {
+				return err
+			}
+			output.SetFormat(format)
+
+			if timeout <= 0 {
+				return nil
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+			timeoutCancel = cancel
+			return nil
+		},
+		PersistentPostRun: func(_ *cobra.Command, _ []string) {
+			if timeoutCancel != nil {
+				timeoutCancel()
+			}
+		},
 	}
 
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 0,
+		"Cancel the command if it runs longer than this (0 disables the timeout)")
+	cmd.PersistentFlags().StringVarP(&format, "output", "o", "text",
+		"Output format: text, json, or yaml")
+
 	cmd.AddCommand(
 		NewVersionCmd(),
 		NewStatusCmd(),
 		NewWorkspaceCmd(),
 		NewHookCmd(),
 		NewInitCmd(),
+		NewBackupCmd(),
+		NewRestoreCmd(),
+		NewUpdateCmd(),
+		NewBridgeCmd(),
+		NewInboxCmd(),
+		NewDaemonCmd(),
 	)
 
 	return cmd
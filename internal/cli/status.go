@@ -2,8 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os/exec"
 
+	"github.com/ryantking/agentctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -23,8 +25,9 @@ func NewStatusCmd() *cobra.Command {
 		Short: "Show the status of Claude Code",
 		RunE: func(_ *cobra.Command, _ []string) error {
 			info := getClaudeInfo()
-			printStatus(info)
-			return nil
+			return output.CurrentWriter().Emit(info, func(w io.Writer) {
+				printStatus(w, info)
+			})
 		},
 	}
 	return cmd
@@ -51,21 +54,21 @@ func getClaudeInfo() StatusInfo {
 	return info
 }
 
-func printStatus(info StatusInfo) {
-	fmt.Println("\n  Claude Code")
-	fmt.Println("  " + "----------------------------------------")
+func printStatus(w io.Writer, info StatusInfo) {
+	fmt.Fprintln(w, "\n  Claude Code")
+	fmt.Fprintln(w, "  "+"----------------------------------------")
 	if info.Claude.Installed {
-		fmt.Print("  Status:   ")
-		fmt.Println("installed")
+		fmt.Fprint(w, "  Status:   ")
+		fmt.Fprintln(w, "installed")
 		version := info.Claude.Version
 		if version == "" {
 			version = "unknown"
 		}
-		fmt.Printf("  Version:  %s\n", version)
-		fmt.Printf("  Path:     %s\n", info.Claude.Path)
+		fmt.Fprintf(w, "  Version:  %s\n", version)
+		fmt.Fprintf(w, "  Path:     %s\n", info.Claude.Path)
 	} else {
-		fmt.Print("  Status:   ")
-		fmt.Println("not installed")
+		fmt.Fprint(w, "  Status:   ")
+		fmt.Fprintln(w, "not installed")
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
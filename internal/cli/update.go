@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/manifest"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/updater"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateCmd creates the update command.
+func NewUpdateCmd() *cobra.Command {
+	var check, apply, openPR bool
+	var platform, base, manifestPath string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Check for and apply upstream changes to installed Claude templates",
+		Long:  "Uses .claude/agentctl.lock (written by `agentctl init`) to detect when installed agents, skills, settings, or MCP entries have drifted from the current manifest/embedded templates, three-way merges them against local edits, and optionally opens a PR with the result.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !check && !apply && !openPR {
+				check = true
+			}
+
+			target, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			var mf *manifest.Manifest
+			if manifestPath != "" {
+				mf, err = manifest.Load(manifestPath)
+			} else {
+				mf, err = manifest.LoadDefault()
+			}
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if check {
+				statuses, err := updater.Check(target, mf)
+				if err != nil {
+					output.Error(err)
+					return err
+				}
+				printUpdateCheck(statuses)
+			}
+
+			if !apply && !openPR {
+				return nil
+			}
+
+			results, err := updater.Apply(target, mf)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+			printUpdateApply(results)
+
+			if !openPR {
+				return nil
+			}
+
+			return runUpdatePR(cmd.Context(), target, results, platform, base)
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "List installed items with upstream changes (default when no flag is given)")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Three-way merge upstream changes into installed items")
+	cmd.Flags().BoolVar(&openPR, "pr", false, "Commit the merged result to a new branch and open a PR (implies --apply)")
+	cmd.Flags().StringVar(&platform, "platform", "", "Forge to open the PR on (\"github\", \"gitlab\", or \"gitea\"); defaults to shelling out to gh")
+	cmd.Flags().StringVar(&base, "base", "main", "Base branch for the PR")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Path to an agentctl.yaml manifest (defaults to the embedded manifest)")
+
+	return cmd
+}
+
+func printUpdateCheck(statuses []updater.ItemStatus) {
+	changed := 0
+	for _, s := range statuses {
+		if !s.UpstreamChanged {
+			continue
+		}
+		changed++
+		note := ""
+		if s.LocallyModified {
+			note = " (locally modified)"
+		}
+		fmt.Printf("  • %s [%s]%s\n", s.Entry.Path, s.Entry.Kind, note)
+	}
+	if changed == 0 {
+		fmt.Println("Everything is up to date.")
+		return
+	}
+	fmt.Printf("%d item(s) have upstream changes\n", changed)
+}
+
+func printUpdateApply(results []updater.MergeResult) {
+	conflicts := 0
+	merged := 0
+	for _, r := range results {
+		if r.Conflict {
+			conflicts++
+			fmt.Printf("  ✗ %s [%s] conflict — resolve manually\n", r.Entry.Path, r.Entry.Kind)
+		}
+	}
+	for _, r := range results {
+		if !r.Conflict {
+			merged++
+		}
+	}
+	fmt.Printf("Applied %d item(s), %d conflict(s)\n", merged, conflicts)
+}
+
+func runUpdatePR(ctx context.Context, target string, results []updater.MergeResult, platform, base string) error {
+	dateStamp := time.Now().UTC().Format("20060102")
+	title := fmt.Sprintf("Update Claude templates (%s)", dateStamp)
+
+	conflicts := 0
+	for _, r := range results {
+		if r.Conflict {
+			conflicts++
+		}
+	}
+	body := fmt.Sprintf("Automated template update from `agentctl update --pr`.\n\n%d item(s) merged, %d conflict(s) left with markers for manual resolution.", len(results)-conflicts, conflicts)
+
+	branch, err := updater.CommitUpdateBranch(target, dateStamp, title)
+	if err != nil {
+		output.Error(err)
+		return err
+	}
+
+	pr, err := updater.OpenUpdatePR(ctx, target, branch, base, title, body, platform)
+	if err != nil {
+		output.Error(err)
+		return err
+	}
+
+	fmt.Printf("Opened %s\n", pr.URL)
+	return nil
+}
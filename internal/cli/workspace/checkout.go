@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// checkoutResult is the typed value NewWorkspaceCheckoutCmd hands to
+// output.Writer.Emit.
+type checkoutResult struct {
+	Branch     string `json:"branch" yaml:"branch"`
+	CheckedOut string `json:"checked_out" yaml:"checked_out"`
+}
+
+// NewWorkspaceCheckoutCmd creates the workspace checkout command.
+func NewWorkspaceCheckoutCmd() *cobra.Command {
+	var create, hash string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "checkout [branch]",
+		Short: "Check out a ref in an existing workspace's worktree",
+		Long: `Operates on an existing workspace's worktree using go-git directly, without
+shelling out to git. With no flags, re-attaches the worktree to its own
+branch (useful after a prior --hash checkout); --create <name> creates a
+new branch from the workspace's current HEAD instead; --hash <sha> detaches
+to a specific commit. If no branch is provided, opens an interactive picker.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			workspaces, err := manager.ListWorkspaces(true)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := manager.CheckoutWorkspace(branch, workspace.CheckoutOptions{
+				Create: create,
+				Hash:   hash,
+				Force:  force,
+			}); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			checkedOut := branch
+			switch {
+			case create != "":
+				checkedOut = create
+			case hash != "":
+				checkedOut = hash
+			}
+
+			result := checkoutResult{Branch: branch, CheckedOut: checkedOut}
+
+			if jsonMode {
+				return output.SuccessJSON(result)
+			}
+
+			return output.CurrentWriter().Emit(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Checked out %s in workspace %s\n", checkedOut, branch)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&create, "create", "", "Create a new branch with this name from the workspace's current HEAD")
+	cmd.Flags().StringVar(&hash, "hash", "", "Detach to this commit instead of a branch")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force the checkout, discarding conflicting local changes")
+
+	return cmd
+}
@@ -3,6 +3,7 @@ package workspace
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ryantking/agentctl/internal/output"
 	"github.com/ryantking/agentctl/internal/workspace"
@@ -11,6 +12,8 @@ import (
 
 // NewWorkspaceCleanCmd creates the workspace clean command.
 func NewWorkspaceCleanCmd() *cobra.Command {
+	var lockTimeout time.Duration
+
 	cmd := &cobra.Command{
 		Use:   "clean",
 		Short: "Remove all clean workspaces",
@@ -26,6 +29,7 @@ func NewWorkspaceCleanCmd() *cobra.Command {
 				output.Error(err)
 				return err
 			}
+			manager.SetLockTimeout(lockTimeout)
 
 			removed, err := manager.CleanWorkspaces(true)
 			if err != nil {
@@ -61,5 +65,8 @@ func NewWorkspaceCleanCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", workspace.DefaultLockTimeout,
+		"How long to wait for the workspace lock before giving up (0 blocks indefinitely)")
+
 	return cmd
 }
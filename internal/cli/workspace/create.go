@@ -2,7 +2,9 @@ package workspace
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/ryantking/agentctl/internal/context"
 	"github.com/ryantking/agentctl/internal/output"
@@ -10,9 +12,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// createResult is the typed value NewWorkspaceCreateCmd hands to
+// output.Writer.Emit, replacing the untyped map[string]interface{} the
+// --json flag used to build.
+type createResult struct {
+	Path   string `json:"path" yaml:"path"`
+	Branch string `json:"branch" yaml:"branch"`
+	Commit string `json:"commit" yaml:"commit"`
+}
+
 // NewWorkspaceCreateCmd creates the workspace create command.
 func NewWorkspaceCreateCmd() *cobra.Command {
 	var baseBranch string
+	var lockTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "create <branch>",
@@ -32,8 +44,9 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 				output.Error(err)
 				return err
 			}
+			manager.SetLockTimeout(lockTimeout)
 
-			ws, err := manager.CreateWorkspace(branch, baseBranch)
+			ws, err := manager.CreateWorkspace(cmd.Context(), branch, baseBranch)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -51,26 +64,24 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 				}
 			}
 
-			data := map[string]interface{}{
-				"path":   ws.Path,
-				"branch": ws.Branch,
-				"commit": ws.Commit,
-			}
+			result := createResult{Path: ws.Path, Branch: ws.Branch, Commit: ws.Commit}
 
 			if jsonMode {
-				return output.SuccessJSON(data)
+				return output.SuccessJSON(result)
 			}
 
-			fmt.Printf("Created workspace: %s\n", ws.Path)
-			if len(copiedFiles) > 0 {
-				fmt.Printf("Copied context: %v\n", copiedFiles)
-			}
-
-			return nil
+			return output.CurrentWriter().Emit(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Created workspace: %s\n", ws.Path)
+				if len(copiedFiles) > 0 {
+					fmt.Fprintf(w, "Copied context: %v\n", copiedFiles)
+				}
+			})
 		},
 	}
 
 	cmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch to create from (defaults to current branch)")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", workspace.DefaultLockTimeout,
+		"How long to wait for the workspace lock before giving up (0 blocks indefinitely)")
 
 	return cmd
 }
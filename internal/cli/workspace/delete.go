@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ryantking/agentctl/internal/output"
 	"github.com/ryantking/agentctl/internal/ui"
@@ -12,11 +13,12 @@ import (
 // NewWorkspaceDeleteCmd creates the workspace delete command.
 func NewWorkspaceDeleteCmd() *cobra.Command {
 	var force bool
+	var lockTimeout time.Duration
 
 	cmd := &cobra.Command{
-		Use:               "delete [branch]",
-		Short:             "Delete a workspace",
-		Long:              `By default, only deletes workspaces with no uncommitted changes.
+		Use:   "delete [branch]",
+		Short: "Delete a workspace",
+		Long: `By default, only deletes workspaces with no uncommitted changes.
 Use --force to delete even with changes (WARNING: data loss). If no branch is provided, opens an interactive picker.`,
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completeWorkspaceNames,
@@ -31,6 +33,7 @@ Use --force to delete even with changes (WARNING: data loss). If no branch is pr
 				output.Error(err)
 				return err
 			}
+			manager.SetLockTimeout(lockTimeout)
 
 			workspaces, err := manager.ListWorkspaces(true)
 			if err != nil {
@@ -41,7 +44,7 @@ Use --force to delete even with changes (WARNING: data loss). If no branch is pr
 				return err
 			}
 
-			branch, err := ui.GetWorkspaceArg(args, workspaces)
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -72,6 +75,8 @@ Use --force to delete even with changes (WARNING: data loss). If no branch is pr
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force deletion even if workspace has uncommitted changes")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", workspace.DefaultLockTimeout,
+		"How long to wait for the workspace lock before giving up (0 blocks indefinitely)")
 
 	return cmd
 }
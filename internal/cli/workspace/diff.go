@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceDiffCmd creates the workspace diff command.
+func NewWorkspaceDiffCmd() *cobra.Command { //nolint:gocyclo // Complex command setup with multiple output formats
+	var target string
+	var format string
+	var findRenames int
+
+	cmd := &cobra.Command{
+		Use:               "diff <branch>",
+		Short:             "Show the diff between a workspace and a target branch",
+		Long:              "Computes a structured diff (added/modified/deleted/renamed files, hunks, and stats) between a workspace's HEAD and --target.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			ws, err := manager.GetWorkspace(branch)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			opts := workspace.DiffOptions{
+				FindRenames:         findRenames > 0,
+				SimilarityThreshold: findRenames,
+			}
+
+			result, err := manager.GetWorkspaceDiffStructured(ws, target, opts)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode || format == "json" {
+				return output.SuccessJSON(result)
+			}
+
+			return renderDiff(result, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&target, "target", "main", "Target branch to diff against")
+	cmd.Flags().StringVar(&format, "format", "unified", "Output format: unified|json|stat|name-only")
+	cmd.Flags().IntVar(&findRenames, "find-renames", 0, "Enable rename detection with the given similarity threshold (0 disables)")
+
+	return cmd
+}
+
+func renderDiff(result *workspace.DiffResult, format string) error {
+	switch format {
+	case "name-only":
+		for _, e := range result.Entries {
+			fmt.Println(e.Path)
+		}
+	case "stat":
+		for _, e := range result.Entries {
+			fmt.Printf("%-8s %-50s +%d -%d\n", e.Status, e.Path, e.Additions, e.Deletions)
+		}
+		fmt.Printf("%d file(s) changed, %d insertion(s), %d deletion(s)\n",
+			result.Stats.FilesChanged, result.Stats.Additions, result.Stats.Deletions)
+	default: // unified
+		for _, e := range result.Entries {
+			for _, hunk := range e.Hunks {
+				fmt.Print(hunk)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspacePRCmd creates the workspace pr command.
+func NewWorkspacePRCmd() *cobra.Command {
+	var title, body, base, providerName string
+	var draft bool
+
+	cmd := &cobra.Command{
+		Use:               "pr <branch>",
+		Short:             "Open a pull/merge request from a workspace",
+		Long:              "Pushes the workspace's branch and opens a pull request (GitHub) or merge request (GitLab) against --base, auto-generating title/body from the commit range when not given explicitly.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			pr, err := manager.OpenWorkspacePR(cmd.Context(), branch, workspace.PROptions{
+				Base:     base,
+				Title:    title,
+				Body:     body,
+				Draft:    draft,
+				Provider: providerName,
+			})
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(pr)
+			}
+
+			fmt.Printf("Opened %s\n", pr.URL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "Pull/merge request title (auto-generated from commits if omitted)")
+	cmd.Flags().StringVar(&body, "body", "", "Pull/merge request body (auto-generated from commits if omitted)")
+	cmd.Flags().StringVar(&base, "base", "main", "Base branch to open the request against")
+	cmd.Flags().StringVar(&providerName, "provider", "", "Remote provider override (github|gitlab|gitea); auto-detected from the origin remote if omitted")
+	cmd.Flags().BoolVar(&draft, "draft", false, "Open as a draft pull/merge request")
+
+	return cmd
+}
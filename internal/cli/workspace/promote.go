@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspacePromoteCmd creates the workspace promote command.
+func NewWorkspacePromoteCmd() *cobra.Command {
+	var onto string
+
+	cmd := &cobra.Command{
+		Use:               "promote <branch>",
+		Short:             "Squash-merge a workspace's service branch snapshots onto its branch",
+		Long:              "Squash-merges the accumulated snapshots on refs/agentctl/service/<branch> onto --onto (defaults to branch itself) as a single commit, without disturbing the service branch ref.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			snapshot, err := manager.PromoteServiceBranch(branch, onto)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(snapshot)
+			}
+
+			fmt.Printf("Promoted %s -> %s (%s)\n", branch, snapshot.Ref, snapshot.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&onto, "onto", "", "Branch to promote onto (defaults to the workspace's own branch)")
+
+	return cmd
+}
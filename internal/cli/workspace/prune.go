@@ -0,0 +1,79 @@
+package workspace
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspacePruneCmd creates the workspace prune command.
+func NewWorkspacePruneCmd() *cobra.Command {
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove stale worktree metadata left by deleted workspaces",
+		Long: `Walks .git/worktrees and removes entries whose worktree directory no
+longer exists, e.g. because it was deleted with rm -rf instead of
+"workspace remove". Unlike "workspace clean", this never touches a workspace
+directory that's still on disk.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			lifecycle, err := workspace.NewLifecycle(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			ctx, cancel := lockContext(cmd.Context(), lockTimeout)
+			defer cancel()
+
+			pruned, err := lifecycle.Prune(ctx)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{
+				"pruned": pruned,
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+
+			if len(pruned) == 0 {
+				fmt.Println("No stale worktree entries to prune")
+				return nil
+			}
+
+			fmt.Printf("Pruned %d stale worktree entr(ies): %v\n", len(pruned), pruned)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", workspace.DefaultLockTimeout,
+		"How long to wait for the workspace lock before giving up (0 blocks indefinitely)")
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceRemoveCmd creates the workspace remove command. It's
+// equivalent to `workspace delete` but goes through workspace.Lifecycle, so
+// it also cleans up the `.git/worktrees/<name>` entry the worktree leaves
+// behind.
+func NewWorkspaceRemoveCmd() *cobra.Command {
+	var force bool
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "remove [branch]",
+		Short: "Remove a workspace and its worktree metadata",
+		Long: `By default, only removes workspaces with no uncommitted changes.
+Use --force to remove even with changes (WARNING: data loss). If no branch is provided, opens an interactive picker.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, err := git.GetRepoRoot(cmd.Context())
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			manager, err := workspace.NewManagerAt(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+			manager.SetLockTimeout(lockTimeout)
+
+			workspaces, err := manager.ListWorkspaces(true)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			lifecycle, err := workspace.NewLifecycle(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			ctx, cancel := lockContext(cmd.Context(), lockTimeout)
+			defer cancel()
+
+			if err := lifecycle.Close(ctx, branch, force); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			data := map[string]interface{}{
+				"branch": branch,
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(data)
+			}
+
+			fmt.Printf("Removed workspace for branch: %s\n", branch)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force removal even if workspace has uncommitted changes")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", workspace.DefaultLockTimeout,
+		"How long to wait for the workspace lock before giving up (0 blocks indefinitely)")
+
+	return cmd
+}
+
+// lockContext derives a context bounded by timeout, unless timeout is zero,
+// in which case it blocks indefinitely (same convention as
+// WorkspaceManager.SetLockTimeout).
+func lockContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
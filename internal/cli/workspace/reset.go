@@ -0,0 +1,109 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// resetResult is the typed value NewWorkspaceResetCmd hands to
+// output.Writer.Emit.
+type resetResult struct {
+	Branch string `json:"branch" yaml:"branch"`
+}
+
+// NewWorkspaceResetCmd creates the workspace reset command.
+func NewWorkspaceResetCmd() *cobra.Command {
+	var hard, mixed, force bool
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "reset [branch]",
+		Short: "Reset an existing workspace's worktree",
+		Long: `Resets a workspace's worktree to its current HEAD (or --to a specific
+commit) using go-git directly. Defaults to a mixed reset; --hard also
+discards uncommitted changes in the working tree and is refused unless the
+workspace is already clean or --force is given. If no branch is provided,
+opens an interactive picker.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			if hard && mixed {
+				err := fmt.Errorf("--hard and --mixed are mutually exclusive")
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			mode := gogit.MixedReset
+			if hard {
+				mode = gogit.HardReset
+			}
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			workspaces, err := manager.ListWorkspaces(true)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := manager.ResetWorkspace(branch, workspace.ResetOptions{
+				Mode:  mode,
+				To:    to,
+				Force: force,
+			}); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			result := resetResult{Branch: branch}
+
+			if jsonMode {
+				return output.SuccessJSON(result)
+			}
+
+			return output.CurrentWriter().Emit(result, func(w io.Writer) {
+				fmt.Fprintf(w, "Reset workspace %s\n", branch)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&hard, "hard", false, "Reset the index and working tree, discarding uncommitted changes")
+	cmd.Flags().BoolVar(&mixed, "mixed", false, "Reset the index but leave the working tree (default)")
+	cmd.Flags().StringVar(&to, "to", "", "Commit-ish to reset to (defaults to the workspace's current HEAD)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Allow --hard even if the workspace has uncommitted changes")
+
+	return cmd
+}
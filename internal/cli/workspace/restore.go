@@ -0,0 +1,54 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceRestoreCmd creates the workspace restore command.
+func NewWorkspaceRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "restore <branch> [snapshot-id]",
+		Short:             "Restore a workspace's working tree from a service-branch snapshot",
+		Long:              "Writes the tree recorded by a prior `workspace snapshot` back into the workspace's working directory. With no snapshot-id, restores the most recent snapshot.",
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+			var snapshotID string
+			if len(args) > 1 {
+				snapshotID = args[1]
+			}
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := manager.RestoreWorkspace(branch, snapshotID); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]string{"branch": branch, "snapshot": snapshotID})
+			}
+
+			fmt.Printf("Restored %s from snapshot\n", branch)
+			return nil
+		},
+	}
+
+	return cmd
+}
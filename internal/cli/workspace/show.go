@@ -38,7 +38,7 @@ func NewWorkspaceShowCmd() *cobra.Command {
 				return err
 			}
 
-			branch, err := ui.GetWorkspaceArg(args, workspaces)
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceSnapshotCmd creates the workspace snapshot command.
+func NewWorkspaceSnapshotCmd() *cobra.Command {
+	var push bool
+	var exclude []string
+
+	cmd := &cobra.Command{
+		Use:               "snapshot <branch>",
+		Short:             "Snapshot a workspace's working tree to its service branch",
+		Long:              "Commits the workspace's current filesystem state — including uncommitted and untracked files — to refs/agentctl/service/<branch>, without touching the checked-out branch or the index.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			snapshot, err := manager.SnapshotWorkspace(branch, workspace.SnapshotOptions{
+				ExcludeGlobs: exclude,
+				Push:         push,
+			})
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(snapshot)
+			}
+
+			fmt.Printf("Snapshotted %s -> %s (%s)\n", branch, snapshot.Ref, snapshot.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&push, "push", false, "Push the service branch ref to origin after committing")
+	cmd.Flags().StringArrayVar(&exclude, "exclude", nil, "Additional gitignore-style pattern to exclude (repeatable)")
+
+	return cmd
+}
@@ -38,7 +38,7 @@ func NewWorkspaceStatusCmd() *cobra.Command {
 				return err
 			}
 
-			branch, err := ui.GetWorkspaceArg(args, workspaces)
+			branch, err := ui.GetWorkspaceArg(cmd.Context(), args, workspaces)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -75,7 +75,16 @@ func NewWorkspaceStatusCmd() *cobra.Command {
 			fmt.Printf("Commit:    %v\n", statusInfo["commit"])
 			fmt.Printf("Status:    %v\n", statusInfo["status"])
 
-			if aheadBehind, ok := statusInfo["ahead_behind"].(map[string]int); ok {
+			if upstreams, ok := statusInfo["upstreams"].([]workspace.UpstreamStatus); ok {
+				for _, u := range upstreams {
+					if u.MergeBase != "" {
+						fmt.Printf("Sync:      %d ahead, %d behind %s (merge-base %s)\n",
+							u.Ahead, u.Behind, u.Name, u.MergeBase[:8])
+					} else {
+						fmt.Printf("Sync:      %d ahead, %d behind %s\n", u.Ahead, u.Behind, u.Name)
+					}
+				}
+			} else if aheadBehind, ok := statusInfo["ahead_behind"].(map[string]int); ok {
 				fmt.Printf("Sync:      %d ahead, %d behind origin\n", aheadBehind["ahead"], aheadBehind["behind"])
 			}
 
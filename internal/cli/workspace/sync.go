@@ -0,0 +1,98 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceSyncCmd creates the workspace sync command.
+func NewWorkspaceSyncCmd() *cobra.Command {
+	var rebase, merge bool
+	var remote, onto string
+	var fetch, prune, force, stash bool
+
+	cmd := &cobra.Command{
+		Use:               "sync <branch>",
+		Short:             "Advance a workspace against its base branch",
+		Long:              "Fast-forwards (default), rebases (--rebase), or merges (--merge) a workspace's branch against its upstream.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			branch := args[0]
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			strategy := workspace.SyncFastForward
+			switch {
+			case rebase:
+				strategy = workspace.SyncRebase
+			case merge:
+				strategy = workspace.SyncMerge
+			}
+
+			opts := workspace.SyncOptions{
+				Strategy: strategy,
+				Remote:   remote,
+				Onto:     onto,
+				Fetch:    fetch,
+				Prune:    prune,
+				Force:    force,
+				Stash:    stash,
+			}
+
+			report, err := manager.SyncWorkspace(branch, opts)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			notifyMsg := fmt.Sprintf("Synced %s: %d ref(s) updated, %d commit(s) replayed", branch, len(report.UpdatedRefs), report.CommitsReplayed)
+			_ = hook.NotifySync(notifyMsg)
+
+			if jsonMode {
+				return output.SuccessJSON(report)
+			}
+
+			return output.CurrentWriter().Emit(report, func(w io.Writer) {
+				fmt.Fprintf(w, "Synced %s (%s): %d ref(s) updated, %d commit(s) replayed\n",
+					branch, strategy, len(report.UpdatedRefs), report.CommitsReplayed)
+				if report.StashRef != "" {
+					fmt.Fprintf(w, "Stashed %d file(s) to %s\n", len(report.FilesChanged), report.StashRef)
+				}
+				if len(report.Conflicts) > 0 {
+					fmt.Fprintln(w, "Conflicts:")
+					for _, c := range report.Conflicts {
+						fmt.Fprintf(w, "  - %s\n", c)
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&rebase, "rebase", false, "Rebase local commits onto the updated base")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge the updated base into the workspace branch")
+	cmd.Flags().StringVar(&remote, "remote", "origin", "Remote to sync against")
+	cmd.Flags().StringVar(&onto, "onto", "", "Base branch to sync against (defaults to the workspace's own branch)")
+	cmd.Flags().BoolVar(&fetch, "fetch", true, "Fetch from the remote before syncing")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Prune deleted remote refs during fetch")
+	cmd.Flags().BoolVar(&force, "force", false, "Bypass the dirty-worktree check")
+	cmd.Flags().BoolVar(&stash, "stash", false, "Auto-stash uncommitted changes before syncing, instead of refusing")
+
+	return cmd
+}
@@ -21,7 +21,17 @@ func NewWorkspaceCmd() *cobra.Command {
 		NewWorkspaceShowCmd(),
 		NewWorkspaceStatusCmd(),
 		NewWorkspaceDeleteCmd(),
+		NewWorkspaceRemoveCmd(),
 		NewWorkspaceCleanCmd(),
+		NewWorkspacePruneCmd(),
+		NewWorkspaceCheckoutCmd(),
+		NewWorkspaceResetCmd(),
+		NewWorkspaceDiffCmd(),
+		NewWorkspaceSyncCmd(),
+		NewWorkspaceSnapshotCmd(),
+		NewWorkspaceRestoreCmd(),
+		NewWorkspacePromoteCmd(),
+		NewWorkspacePRCmd(),
 	)
 
 	return cmd
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/hcl"
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies a settings file's on-disk encoding.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+	FormatTOML
+	FormatHCL
+)
+
+// ErrUnsupportedFormat is returned by Load/Save for an unrecognized Format.
+var ErrUnsupportedFormat = fmt.Errorf("unsupported settings format")
+
+// ErrHCLSaveUnsupported is returned by Save for FormatHCL: HCL has no
+// general-purpose encoder, only a decoder, so settings can be loaded from
+// HCL but not written back out as HCL.
+var ErrHCLSaveUnsupported = fmt.Errorf("saving settings as HCL is not supported")
+
+// Load parses data in the given format into the same map[string]interface{}
+// shape Merge and MergeStrategic consume, regardless of source format.
+func Load(data []byte, format Format) (map[string]interface{}, error) {
+	switch format {
+	case FormatJSON:
+		return LoadJSON(data)
+	case FormatYAML:
+		var settings map[string]interface{}
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		return toSettingsMap(canonicalize(settings)), nil
+	case FormatTOML:
+		var settings map[string]interface{}
+		if err := toml.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML: %w", err)
+		}
+		return toSettingsMap(canonicalize(settings)), nil
+	case FormatHCL:
+		var settings map[string]interface{}
+		if err := hcl.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse HCL: %w", err)
+		}
+		return toSettingsMap(canonicalize(settings)), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, format)
+	}
+}
+
+// Save encodes settings into the given format.
+func Save(settings map[string]interface{}, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return SaveJSON(settings)
+	case FormatYAML:
+		data, err := yaml.Marshal(settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		return data, nil
+	case FormatTOML:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(settings); err != nil {
+			return nil, fmt.Errorf("failed to encode TOML: %w", err)
+		}
+		return buf.Bytes(), nil
+	case FormatHCL:
+		return nil, ErrHCLSaveUnsupported
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, format)
+	}
+}
+
+// FormatFromExtension maps a file extension (as returned by filepath.Ext,
+// with or without the leading dot) to a Format. Returns an error for any
+// extension we don't recognize.
+func FormatFromExtension(ext string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(ext, ".")) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	case "hcl":
+		return FormatHCL, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedFormat, ext)
+	}
+}
+
+// LoadFile reads and parses a settings file, picking the format from its
+// extension.
+func LoadFile(path string) (map[string]interface{}, error) {
+	format, err := FormatFromExtension(filepath.Ext(path))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return Load(data, format)
+}
+
+// toSettingsMap coerces a canonicalize result back to the map[string]interface{}
+// shape Load promises callers. An empty or all-null document canonicalizes to
+// something other than a map (nil, or a scalar/list if the source format
+// doesn't reject non-map top-level documents the way YAML/TOML/HCL's decoders
+// already do); rather than a failed type assertion, that's just an empty
+// settings map.
+func toSettingsMap(v interface{}) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// canonicalize recursively flattens HCL's repeated-block decoding artifact,
+// where a single nested block decodes as []map[string]interface{} of length
+// one instead of a plain map[string]interface{}, so settings loaded from HCL
+// have the same shape as settings loaded from JSON/YAML/TOML and Merge
+// behaves identically regardless of source format.
+func canonicalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []map[string]interface{}:
+		if len(val) == 1 {
+			return canonicalize(val[0])
+		}
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = canonicalize(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = canonicalize(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = canonicalize(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestLoadSaveRoundTripAcrossFormats(t *testing.T) {
+	yamlDoc := []byte("title: base\nhooks:\n  pre-commit: lint\n")
+
+	base, err := Load(yamlDoc, FormatYAML)
+	if err != nil {
+		t.Fatalf("Load(YAML) failed: %v", err)
+	}
+
+	overlay := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"pre-push": "test",
+		},
+		"enabled": true,
+	}
+
+	merged := Merge(base, overlay)
+
+	data, err := Save(merged, FormatTOML)
+	if err != nil {
+		t.Fatalf("Save(TOML) failed: %v", err)
+	}
+
+	reloaded, err := Load(data, FormatTOML)
+	if err != nil {
+		t.Fatalf("Load(TOML) failed: %v", err)
+	}
+
+	if reloaded["title"] != "base" {
+		t.Errorf("expected title to survive the round trip, got %v", reloaded["title"])
+	}
+	if reloaded["enabled"] != true {
+		t.Errorf("expected enabled to survive the round trip, got %v", reloaded["enabled"])
+	}
+
+	hooks, ok := reloaded["hooks"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected hooks to be a map, got %T", reloaded["hooks"])
+	}
+	if hooks["pre-commit"] != "lint" || hooks["pre-push"] != "test" {
+		t.Errorf("expected both hooks to survive the merge and round trip, got %v", hooks)
+	}
+}
+
+func TestFormatFromExtension(t *testing.T) {
+	cases := map[string]Format{
+		"json": FormatJSON,
+		".yml": FormatYAML,
+		"yaml": FormatYAML,
+		"toml": FormatTOML,
+		"hcl":  FormatHCL,
+	}
+	for ext, want := range cases {
+		got, err := FormatFromExtension(ext)
+		if err != nil {
+			t.Fatalf("FormatFromExtension(%q) failed: %v", ext, err)
+		}
+		if got != want {
+			t.Errorf("FormatFromExtension(%q) = %v, want %v", ext, got, want)
+		}
+	}
+
+	if _, err := FormatFromExtension("ini"); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
+
+func TestLoadEmptyDocument(t *testing.T) {
+	for _, format := range []Format{FormatYAML, FormatTOML, FormatHCL} {
+		settings, err := Load([]byte(""), format)
+		if err != nil {
+			t.Fatalf("Load(%v, empty) failed: %v", format, err)
+		}
+		if settings == nil {
+			t.Errorf("Load(%v, empty) returned a nil map, want an empty one", format)
+		}
+		if len(settings) != 0 {
+			t.Errorf("Load(%v, empty) = %v, want empty", format, settings)
+		}
+	}
+}
+
+func TestLoadNullYAMLDocument(t *testing.T) {
+	settings, err := Load([]byte("null\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("Load(YAML, null) failed: %v", err)
+	}
+	if len(settings) != 0 {
+		t.Errorf("Load(YAML, null) = %v, want empty", settings)
+	}
+}
+
+func TestCanonicalizeFlattensHCLSingleBlock(t *testing.T) {
+	raw := map[string]interface{}{
+		"server": []map[string]interface{}{
+			{"host": "localhost"},
+		},
+	}
+
+	got := canonicalize(raw).(map[string]interface{})
+	server, ok := got["server"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected server to be flattened to a map, got %T", got["server"])
+	}
+	if server["host"] != "localhost" {
+		t.Errorf("expected host to survive flattening, got %v", server["host"])
+	}
+}
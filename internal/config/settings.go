@@ -4,43 +4,214 @@ package config
 import (
 	"encoding/json"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
+// MergeOptions controls how MergeStrategic combines overlay lists with base
+// lists.
+type MergeOptions struct {
+	// ListMergeKeys maps a dotted path (e.g. "hooks.PostToolUse") to the
+	// field name used to match elements within the list at that path,
+	// instead of the default append/dedupe behavior: elements on both
+	// sides sharing a value for that field are merged recursively, and
+	// unmatched overlay elements are appended.
+	ListMergeKeys map[string]string
+	// CaseInsensitive treats map keys as case-insensitive when deciding
+	// whether an overlay key already exists in base, preserving the
+	// casing of the first (base) occurrence in the result.
+	CaseInsensitive bool
+}
+
 // Merge performs a deep merge of settings with intelligent array handling.
 // Strategy:
 // - Nested maps: Recursive merge
 // - Arrays: Union (deduplicate simple types)
 // - Scalars: Overlay takes precedence
+//
+// It is a convenience wrapper around MergeStrategic with no ListMergeKeys
+// and no strategic merge patch directives.
 func Merge(base, overlay map[string]interface{}) map[string]interface{} {
+	return MergeStrategic(base, overlay, MergeOptions{})
+}
+
+// MergeStrategic merges overlay into base like Merge, but also understands a
+// set of Kubernetes-style strategic merge patch directives so an overlay can
+// precisely control how it combines with the base instead of only
+// union/dedup for arrays:
+//
+//   - A "$patch" key inside a map is "replace" (drop the base subtree and use
+//     the overlay as-is), "delete" (remove that key from the result), or
+//     "merge" (the default recursive-merge behavior).
+//   - "$deleteFromPrimitiveList/<key>" removes the listed scalars from
+//     result[key].
+//   - "$setElementOrder/<key>" reorders the merged list at result[key] to
+//     match the given order, appending any elements it doesn't mention.
+//
+// opts.ListMergeKeys lets a list at a given dotted path (e.g.
+// "hooks.PostToolUse") be merged by matching a key field instead of being
+// appended/deduplicated, so an overlay can patch a single element (e.g. one
+// hook matcher) without repeating the rest of the array.
+func MergeStrategic(base, overlay map[string]interface{}, opts MergeOptions) map[string]interface{} {
+	return mergeAt("", base, overlay, opts)
+}
+
+// MergeCaseInsensitive merges overlay into base like Merge, but treats map
+// keys as case-insensitive when deciding whether an overlay key already
+// exists in base, preserving the casing of the first (base) occurrence in
+// the result. Borrowed from Hugo's recursive Merge: Claude Code settings
+// casing has historically drifted across ~/.claude, project .claude/, and
+// enterprise policy files.
+func MergeCaseInsensitive(base, overlay map[string]interface{}) map[string]interface{} {
+	return MergeStrategic(base, overlay, MergeOptions{CaseInsensitive: true})
+}
+
+// ApplyOverrides sets each of overrides' dotted-path keys (e.g.
+// "hooks.PostToolUse.0.matcher") directly into a deep copy of base,
+// creating maps or list elements along the way as needed, and returns the
+// result. This lets a caller script per-invocation overrides (e.g. a CLI
+// `--set hooks.PostToolUse.0.command=...` flag) without editing settings
+// files directly. Unlike MergeStrategic, override values are set as-is: a
+// list index addresses one existing element in place rather than being
+// unioned/appended, and a map value isn't interpreted for "$patch" or
+// other strategic merge directives.
+func ApplyOverrides(base, overrides map[string]interface{}) map[string]interface{} {
+	result := deepCopyMap(base)
+	for path, value := range overrides {
+		result = assign(result, strings.Split(path, "."), value).(map[string]interface{})
+	}
+	return result
+}
+
+// deepCopyMap returns an independent copy of m via a JSON round-trip, so
+// ApplyOverrides can grow/replace nested maps and lists in place without
+// mutating the caller's base.
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	var clone map[string]interface{}
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return map[string]interface{}{}
+	}
+	return clone
+}
+
+// assign walks into container via parts, creating maps or slices as needed
+// for each segment (a slice when the next segment parses as an integer
+// index, a map otherwise), and sets value at the final segment. It returns
+// the (possibly newly-created, or grown) container so a caller holding a
+// parent slice index can write the result back into its own slot.
+func assign(container interface{}, parts []string, value interface{}) interface{} {
+	key := parts[0]
+	rest := parts[1:]
+
+	if idx, err := strconv.Atoi(key); err == nil {
+		list, _ := container.([]interface{})
+		for len(list) <= idx {
+			list = append(list, nil)
+		}
+		if len(rest) == 0 {
+			list[idx] = value
+		} else {
+			list[idx] = assign(list[idx], rest, value)
+		}
+		return list
+	}
+
+	m, ok := container.(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+	}
+	if len(rest) == 0 {
+		m[key] = value
+	} else {
+		m[key] = assign(m[key], rest, value)
+	}
+	return m
+}
+
+func mergeAt(path string, base, overlay map[string]interface{}, opts MergeOptions) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range base {
 		result[k] = v
 	}
 
 	for key, value := range overlay {
-		existing, exists := result[key]
 		switch {
+		case key == "$patch":
+			// Handled by the caller merging this map into its parent; at
+			// the root there's nothing to patch against.
+			continue
+		case strings.HasPrefix(key, "$deleteFromPrimitiveList/"):
+			target := strings.TrimPrefix(key, "$deleteFromPrimitiveList/")
+			resolvedTarget, _ := resolveKey(result, target, opts.CaseInsensitive)
+			if existing, ok := result[resolvedTarget].([]interface{}); ok {
+				result[resolvedTarget] = removeListValues(existing, value)
+			}
+			continue
+		case strings.HasPrefix(key, "$setElementOrder/"):
+			target := strings.TrimPrefix(key, "$setElementOrder/")
+			resolvedTarget, _ := resolveKey(result, target, opts.CaseInsensitive)
+			if existing, ok := result[resolvedTarget].([]interface{}); ok {
+				if order, ok := value.([]interface{}); ok {
+					result[resolvedTarget] = reorderList(existing, order, opts.ListMergeKeys[joinPath(path, resolvedTarget)])
+				}
+			}
+			continue
+		}
+
+		resolvedKey, exists := resolveKey(result, key, opts.CaseInsensitive)
+		existing := result[resolvedKey]
+		childPath := joinPath(path, resolvedKey)
+
+		switch {
+		case isMap(value) && patchDirective(value) == "delete":
+			delete(result, resolvedKey)
+		case isMap(value) && patchDirective(value) == "replace":
+			result[resolvedKey] = stripPatchKey(value.(map[string]interface{}))
 		case !exists:
-			// New key - add it
 			result[key] = value
 		case isMap(value) && isMap(existing):
-			// Both maps - recursive merge
-			result[key] = Merge(
-				existing.(map[string]interface{}),
-				value.(map[string]interface{}),
-			)
+			result[resolvedKey] = mergeAt(childPath, existing.(map[string]interface{}), value.(map[string]interface{}), opts)
 		case isSlice(value) && isSlice(existing):
-			// Both slices - merge with deduplication
-			result[key] = mergeLists(existing.([]interface{}), value.([]interface{}))
+			if mergeKey, ok := opts.ListMergeKeys[childPath]; ok {
+				result[resolvedKey] = mergeKeyedLists(existing.([]interface{}), value.([]interface{}), mergeKey, childPath, opts)
+			} else {
+				result[resolvedKey] = mergeLists(existing.([]interface{}), value.([]interface{}))
+			}
 		default:
 			// Scalar or type mismatch - overlay wins
-			result[key] = value
+			result[resolvedKey] = value
 		}
 	}
 
 	return result
 }
 
+// patchDirective returns the map's "$patch" value ("replace", "delete", ...)
+// or "" if it has none or isn't a map.
+func patchDirective(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	patch, _ := m["$patch"].(string)
+	return patch
+}
+
+func stripPatchKey(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k == "$patch" {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
 func mergeLists(base, overlay []interface{}) []interface{} {
 	result := make([]interface{}, len(base))
 	copy(result, base)
@@ -67,12 +238,150 @@ func mergeLists(base, overlay []interface{}) []interface{} {
 	return result
 }
 
+// mergeKeyedLists merges overlay into base by matching elements on keyField:
+// matched elements are merged recursively (so an overlay can patch one field
+// of a matched element without repeating the rest), and unmatched overlay
+// elements are appended.
+func mergeKeyedLists(base, overlay []interface{}, keyField, path string, opts MergeOptions) []interface{} {
+	result := make([]interface{}, len(base))
+	copy(result, base)
+
+	for _, item := range overlay {
+		overlayMap, ok := item.(map[string]interface{})
+		if !ok {
+			result = append(result, item)
+			continue
+		}
+
+		matchVal, ok := overlayMap[keyField]
+		if !ok {
+			result = append(result, item)
+			continue
+		}
+
+		matchedIdx := -1
+		for i, existing := range result {
+			if existingMap, ok := existing.(map[string]interface{}); ok && reflect.DeepEqual(existingMap[keyField], matchVal) {
+				matchedIdx = i
+				break
+			}
+		}
+
+		if matchedIdx < 0 {
+			result = append(result, item)
+			continue
+		}
+
+		existingMap, _ := result[matchedIdx].(map[string]interface{})
+		result[matchedIdx] = mergeAt(path, existingMap, overlayMap, opts)
+	}
+
+	return result
+}
+
+// removeListValues drops every element of list that deep-equals one of
+// toRemove's entries. toRemove that isn't a list is ignored.
+func removeListValues(list []interface{}, toRemove interface{}) []interface{} {
+	removeList, ok := toRemove.([]interface{})
+	if !ok {
+		return list
+	}
+
+	var result []interface{}
+	for _, item := range list {
+		skip := false
+		for _, r := range removeList {
+			if reflect.DeepEqual(item, r) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// reorderList returns existing reordered to match order: elements of
+// existing matching order's entries (by keyField if given, else by deep
+// equality) come first in order's sequence, followed by any elements of
+// existing that order didn't mention.
+func reorderList(existing, order []interface{}, keyField string) []interface{} {
+	used := make(map[int]bool, len(existing))
+	result := make([]interface{}, 0, len(existing))
+
+	for _, wanted := range order {
+		idx := findListElement(existing, wanted, keyField, used)
+		if idx < 0 {
+			continue
+		}
+		result = append(result, existing[idx])
+		used[idx] = true
+	}
+
+	for i, item := range existing {
+		if !used[i] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func findListElement(list []interface{}, wanted interface{}, keyField string, used map[int]bool) int {
+	for i, item := range list {
+		if used[i] {
+			continue
+		}
+		if keyField != "" {
+			itemMap, itemOK := item.(map[string]interface{})
+			wantedMap, wantedOK := wanted.(map[string]interface{})
+			if itemOK && wantedOK && reflect.DeepEqual(itemMap[keyField], wantedMap[keyField]) {
+				return i
+			}
+			continue
+		}
+		if reflect.DeepEqual(item, wanted) {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveKey looks up key in result, optionally case-insensitively,
+// returning the key actually present in result (so its original casing is
+// preserved) and whether it was found. When caseInsensitive is false, or no
+// match is found, it returns key itself.
+func resolveKey(result map[string]interface{}, key string, caseInsensitive bool) (string, bool) {
+	if _, ok := result[key]; ok || !caseInsensitive {
+		_, ok := result[key]
+		return key, ok
+	}
+	for k := range result {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+	return key, false
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
 func isMap(v interface{}) bool {
 	_, ok := v.(map[string]interface{})
 	return ok
 }
 
 func isSlice(v interface{}) bool {
+	if v == nil {
+		return false
+	}
 	return reflect.TypeOf(v).Kind() == reflect.Slice
 }
 
@@ -0,0 +1,83 @@
+package config
+
+import "testing"
+
+func TestMergeNullOverlayValue(t *testing.T) {
+	base := map[string]interface{}{
+		"model": "opus",
+		"hooks": []interface{}{"a", "b"},
+	}
+	overlay := map[string]interface{}{
+		"model": nil,
+	}
+
+	merged := Merge(base, overlay)
+
+	if merged["model"] != nil {
+		t.Errorf("expected model to be nil, got %v", merged["model"])
+	}
+	if _, ok := merged["hooks"].([]interface{}); !ok {
+		t.Errorf("expected hooks to be left untouched, got %v", merged["hooks"])
+	}
+}
+
+func TestMergeNullOverlayAgainstExistingList(t *testing.T) {
+	base := map[string]interface{}{
+		"hooks": []interface{}{"a", "b"},
+	}
+	overlay := map[string]interface{}{
+		"hooks": nil,
+	}
+
+	merged := Merge(base, overlay)
+
+	if merged["hooks"] != nil {
+		t.Errorf("expected hooks to be nil, got %v", merged["hooks"])
+	}
+}
+
+func TestMergeCaseInsensitive(t *testing.T) {
+	base := map[string]interface{}{
+		"Hooks": map[string]interface{}{"PostToolUse": "a"},
+	}
+	overlay := map[string]interface{}{
+		"hooks": map[string]interface{}{"posttooluse": "b"},
+	}
+
+	merged := MergeCaseInsensitive(base, overlay)
+
+	if _, ok := merged["hooks"]; ok {
+		t.Errorf("expected base casing 'Hooks' to be preserved, got keys %v", merged)
+	}
+	nested, ok := merged["Hooks"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected Hooks to be a map, got %v", merged["Hooks"])
+	}
+	if nested["PostToolUse"] != "b" {
+		t.Errorf("expected PostToolUse to be overridden to 'b', got %v", nested["PostToolUse"])
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	base := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"PostToolUse": []interface{}{
+				map[string]interface{}{"matcher": "Edit", "command": "old"},
+			},
+		},
+	}
+
+	merged := ApplyOverrides(base, map[string]interface{}{
+		"hooks.PostToolUse.0.command": "new",
+	})
+
+	hooks := merged["hooks"].(map[string]interface{})
+	postToolUse := hooks["PostToolUse"].([]interface{})
+	entry := postToolUse[0].(map[string]interface{})
+	if entry["command"] != "new" {
+		t.Errorf("expected command to be overridden to 'new', got %v", entry["command"])
+	}
+	if entry["matcher"] != "Edit" {
+		t.Errorf("expected matcher to be left untouched, got %v", entry["matcher"])
+	}
+}
@@ -0,0 +1,68 @@
+// Package daemon installs and manages the long-lived `agentctl daemon run`
+// process that polls and fires scheduler.Schedule entries, via launchd on
+// macOS and a systemd user unit on Linux.
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// label identifies the daemon to launchd/systemd.
+const label = "com.ryantking.agentctl"
+
+// LogPath returns the path `agentctl daemon run` appends its output to, and
+// that `agentctl daemon logs` tails.
+func LogPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentctl", "daemon.log"), nil
+}
+
+// Install generates and registers the platform service unit that runs
+// `agentctl daemon run` in the background, returning the path it wrote.
+func Install() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return installLaunchd()
+	case "linux":
+		return installSystemd()
+	default:
+		return "", fmt.Errorf("agentctl daemon install is not supported on %s", runtime.GOOS)
+	}
+}
+
+// Status reports whether the daemon service is currently registered and
+// running, per the platform's service manager.
+func Status() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return statusLaunchd()
+	case "linux":
+		return statusSystemd()
+	default:
+		return "", fmt.Errorf("agentctl daemon status is not supported on %s", runtime.GOOS)
+	}
+}
+
+// Restart restarts the daemon service.
+func Restart() error {
+	switch runtime.GOOS {
+	case "darwin":
+		return restartLaunchd()
+	case "linux":
+		return restartSystemd()
+	default:
+		return fmt.Errorf("agentctl daemon restart is not supported on %s", runtime.GOOS)
+	}
+}
+
+// agentctlPath resolves the path to the currently running agentctl binary,
+// for the generated service unit's ExecStart/ProgramArguments.
+func agentctlPath() (string, error) {
+	return os.Executable()
+}
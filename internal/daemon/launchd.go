@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// launchdPlistPath returns the path to the generated LaunchAgent plist.
+func launchdPlistPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", label+".plist"), nil
+}
+
+// launchdPlist renders the plist contents for bin, logging to logPath.
+func launchdPlist(bin, logPath string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>daemon</string>
+		<string>run</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>%s</string>
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, label, bin, logPath, logPath)
+}
+
+func installLaunchd() (string, error) {
+	bin, err := agentctlPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve agentctl binary path: %w", err)
+	}
+	logPath, err := LogPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve daemon log path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create daemon log directory: %w", err)
+	}
+
+	plistPath, err := launchdPlistPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve LaunchAgent plist path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(plistPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+	}
+
+	if err := os.WriteFile(plistPath, []byte(launchdPlist(bin, logPath)), 0644); err != nil { //nolint:gosec // Plist needs to be readable by launchd
+		return "", fmt.Errorf("failed to write LaunchAgent plist: %w", err)
+	}
+
+	if err := exec.Command("launchctl", "load", "-w", plistPath).Run(); err != nil {
+		return "", fmt.Errorf("failed to load LaunchAgent: %w", err)
+	}
+	return plistPath, nil
+}
+
+func statusLaunchd() (string, error) {
+	out, err := exec.Command("launchctl", "list", label).Output()
+	if err != nil {
+		return "not running", nil //nolint:nilerr // launchctl exits non-zero when the label isn't loaded
+	}
+	return string(out), nil
+}
+
+func restartLaunchd() error {
+	plistPath, err := launchdPlistPath()
+	if err != nil {
+		return fmt.Errorf("failed to resolve LaunchAgent plist path: %w", err)
+	}
+	_ = exec.Command("launchctl", "unload", plistPath).Run()
+	if err := exec.Command("launchctl", "load", "-w", plistPath).Run(); err != nil {
+		return fmt.Errorf("failed to reload LaunchAgent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/scheduler"
+)
+
+// pollInterval is how often Run checks for due schedules. Schedules are
+// expressed at minute precision, so polling more often than that wouldn't
+// catch anything new.
+const pollInterval = time.Minute
+
+// Run polls scheduler.Load every pollInterval and fires any due schedules,
+// blocking until ctx is canceled. This is what `agentctl daemon run` (the
+// process launchd/systemd keeps alive) executes.
+func Run(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			tick(ctx)
+		}
+	}
+}
+
+// tick loads the schedule store and runs anything due, logging (rather than
+// propagating) errors so one bad schedule doesn't take down the daemon.
+func tick(ctx context.Context) {
+	store, err := scheduler.Load()
+	if err != nil {
+		log.Printf("daemon: failed to load schedules: %v", err)
+		return
+	}
+
+	ran, err := scheduler.RunDue(ctx, store, time.Now())
+	if err != nil {
+		log.Printf("daemon: schedule run error: %v", err)
+	}
+	for _, id := range ran {
+		log.Printf("daemon: ran schedule %s", id)
+	}
+}
@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// systemdUnitPath returns the path to the generated systemd user unit.
+func systemdUnitPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "systemd", "user", "agentctl.service"), nil
+}
+
+// systemdUnit renders the unit contents for bin, logging to logPath.
+func systemdUnit(bin, logPath string) string {
+	return fmt.Sprintf(`[Unit]
+Description=agentctl scheduled hook runner
+
+[Service]
+ExecStart=%s daemon run
+Restart=on-failure
+StandardOutput=append:%s
+StandardError=append:%s
+
+[Install]
+WantedBy=default.target
+`, bin, logPath, logPath)
+}
+
+func installSystemd() (string, error) {
+	bin, err := agentctlPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve agentctl binary path: %w", err)
+	}
+	logPath, err := LogPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve daemon log path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create daemon log directory: %w", err)
+	}
+
+	unitPath, err := systemdUnitPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve systemd unit path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(unitPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create systemd user unit directory: %w", err)
+	}
+
+	if err := os.WriteFile(unitPath, []byte(systemdUnit(bin, logPath)), 0644); err != nil { //nolint:gosec // Unit needs to be readable by systemd
+		return "", fmt.Errorf("failed to write systemd unit: %w", err)
+	}
+
+	if err := exec.Command("systemctl", "--user", "daemon-reload").Run(); err != nil {
+		return "", fmt.Errorf("failed to reload systemd user units: %w", err)
+	}
+	if err := exec.Command("systemctl", "--user", "enable", "--now", "agentctl.service").Run(); err != nil {
+		return "", fmt.Errorf("failed to enable agentctl.service: %w", err)
+	}
+	return unitPath, nil
+}
+
+func statusSystemd() (string, error) {
+	out, err := exec.Command("systemctl", "--user", "status", "agentctl.service").CombinedOutput()
+	if err != nil {
+		return string(out), nil //nolint:nilerr // systemctl exits non-zero for inactive/failed units, which is still a valid status to show
+	}
+	return string(out), nil
+}
+
+func restartSystemd() error {
+	if err := exec.Command("systemctl", "--user", "restart", "agentctl.service").Run(); err != nil {
+		return fmt.Errorf("failed to restart agentctl.service: %w", err)
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 // Package git provides Git repository utilities and worktree management.
+// Operations here read repository state directly through go-git and the
+// on-disk .git layout rather than shelling out to the git CLI; callers
+// that need a shell fallback for features go-git doesn't support (LFS,
+// partial clone) should use internal/gitx instead.
 package git
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,11 +16,14 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 )
 
-
 // GetRepoRoot returns the root directory of the current git repository.
 // Correctly handles worktrees by finding the actual repository root
-// instead of the worktree directory.
-func GetRepoRoot() (string, error) {
+// instead of the worktree directory. ctx is checked before any work starts
+// so a caller with an already-expired timeout fails fast.
+func GetRepoRoot(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	wd, err := filepath.Abs(".")
 	if err != nil {
 		return "", err
@@ -24,8 +32,8 @@ func GetRepoRoot() (string, error) {
 }
 
 // GetRepoName returns the name of the current git repository.
-func GetRepoName() (string, error) {
-	root, err := GetRepoRoot()
+func GetRepoName(ctx context.Context) (string, error) {
+	root, err := GetRepoRoot(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -70,7 +78,7 @@ func GetCurrentBranch(path string) (string, error) {
 	}
 
 	// Regular repo or fallback - use go-git
-	repo, err := OpenRepo(repoRoot)
+	repo, err := OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return "", err
 	}
@@ -89,7 +97,7 @@ func GetCurrentBranch(path string) (string, error) {
 
 // BranchExists checks if a branch exists locally or remotely.
 func BranchExists(repoRoot, branchName string) (bool, error) {
-	repo, err := OpenRepo(repoRoot)
+	repo, err := OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return false, err
 	}
@@ -1,13 +1,14 @@
 package git
 
 import (
+	"context"
 	"os"
 	"testing"
 )
 
 func TestGetRepoRoot(t *testing.T) {
 	// This test requires a git repository
-	repoRoot, err := GetRepoRoot()
+	repoRoot, err := GetRepoRoot(context.Background())
 	if err != nil {
 		t.Skip("Not in a git repository, skipping test")
 	}
@@ -20,7 +21,7 @@ func TestGetRepoRoot(t *testing.T) {
 }
 
 func TestGetRepoName(t *testing.T) {
-	repoName, err := GetRepoName()
+	repoName, err := GetRepoName(context.Background())
 	if err != nil {
 		t.Skip("Not in a git repository, skipping test")
 	}
@@ -30,7 +31,7 @@ func TestGetRepoName(t *testing.T) {
 }
 
 func TestBranchExists(t *testing.T) {
-	repoRoot, err := GetRepoRoot()
+	repoRoot, err := GetRepoRoot(context.Background())
 	if err != nil {
 		t.Skip("Not in a git repository, skipping test")
 	}
@@ -66,7 +67,7 @@ func TestBranchExists(t *testing.T) {
 // reading .git/worktrees directory structure instead of parsing CLI output
 
 func TestIsWorktreeClean(t *testing.T) {
-	repoRoot, err := GetRepoRoot()
+	repoRoot, err := GetRepoRoot(context.Background())
 	if err != nil {
 		t.Skip("Not in a git repository, skipping test")
 	}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Push pushes a worktree's current branch to the given remote, setting
+// upstream tracking on first push. Returns nil if the remote is already
+// up to date.
+func Push(worktreePath, remote, branch string) error {
+	repo, err := OpenRepo(context.Background(), worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s",
+		plumbing.NewBranchReferenceName(branch), plumbing.NewBranchReferenceName(branch)))
+
+	err = repo.Push(&gogit.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{refSpec},
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to push %s to %s: %w", branch, remote, err)
+	}
+	return nil
+}
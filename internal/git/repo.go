@@ -1,12 +1,17 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 )
 
 // Repo wraps a go-git repository and provides convenience methods.
@@ -15,22 +20,69 @@ type Repo struct {
 	path string
 }
 
-// OpenRepo opens a git repository at the given path.
-func OpenRepo(path string) (*Repo, error) {
-	repo, err := git.PlainOpen(path)
+// Root returns the path the repository was opened at.
+func (r *Repo) Root() string {
+	return r.path
+}
+
+// OpenRepo opens a git repository at the given path. ctx is checked before
+// opening so a caller with an already-expired timeout fails fast; opening
+// itself is local disk I/O and isn't otherwise cancellable.
+//
+// Unlike a plain git.PlainOpen, the repository's storage is given an
+// unbounded AlternatesFS (see newAlternatesAwareStorage) so
+// objects/info/alternates, as written by AddWorktree's object-sharing setup,
+// actually resolves.
+func OpenRepo(ctx context.Context, path string) (*Repo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wt := osfs.New(absPath)
+	if _, err := wt.Stat(git.GitDirName); err != nil {
+		if os.IsNotExist(err) {
+			return nil, git.ErrRepositoryNotExists
+		}
+		return nil, err
+	}
+	dot, err := wt.Chroot(git.GitDirName)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := git.Open(newAlternatesAwareStorage(dot), wt)
 	if err != nil {
 		return nil, err
 	}
 	return &Repo{Repository: repo, path: path}, nil
 }
 
+// newAlternatesAwareStorage builds filesystem storage for dotGitFs (a
+// repository's .git directory) with an unbounded AlternatesFS. go-git's
+// default storage chroots AlternatesFS to the repo's own .git directory,
+// which makes an absolute objects/info/alternates path (as git itself
+// writes, and as AddWorktree's linkObjectStore writes) unreachable: the path
+// gets rewritten relative to that chroot and can never resolve outside it.
+// Rooting AlternatesFS at the real filesystem root instead lets it actually
+// follow the absolute path.
+func newAlternatesAwareStorage(dotGitFs billy.Filesystem) *filesystem.Storage {
+	return filesystem.NewStorageWithOptions(dotGitFs, cache.NewObjectLRUDefault(), filesystem.Options{
+		AlternatesFS: osfs.New(string(filepath.Separator)),
+	})
+}
+
 // OpenRepoWithDiscover opens a git repository, discovering the root from the given path.
-func OpenRepoWithDiscover(path string) (*Repo, error) {
+func OpenRepoWithDiscover(ctx context.Context, path string) (*Repo, error) {
 	repoPath, err := discoverRepoRoot(path)
 	if err != nil {
 		return nil, err
 	}
-	return OpenRepo(repoPath)
+	return OpenRepo(ctx, repoPath)
 }
 
 // discoverRepoRoot finds the git repository root by walking up the directory tree.
@@ -62,12 +114,12 @@ func discoverRepoRoot(startPath string) (string, error) {
 						if !filepath.IsAbs(gitDirPath) {
 							gitDirPath = filepath.Join(current, gitDirPath)
 						}
-						
+
 						// For worktrees, the gitDirPath points to .git/worktrees/<name>
 						// The actual repo root is the parent of the .git directory
 						// So we need to go up from .git/worktrees/<name> to .git to get the repo root
 						worktreeGitDir := gitDirPath
-						
+
 						// Check if this is a worktree git dir (contains HEAD, index, etc.)
 						// The actual repo root is 3 levels up: worktrees/<name> -> worktrees -> .git -> repo root
 						if strings.Contains(worktreeGitDir, "/.git/worktrees/") {
@@ -81,14 +133,14 @@ func discoverRepoRoot(startPath string) (string, error) {
 								}
 							}
 						}
-						
+
 						// Fallback: try to find repo root by going up from worktree git dir
 						// The worktree git dir is at .git/worktrees/<name>, so repo root is 3 levels up
 						repoRoot := filepath.Dir(filepath.Dir(filepath.Dir(worktreeGitDir)))
 						if _, err := os.Stat(filepath.Join(repoRoot, ".git")); err == nil {
 							return repoRoot, nil
 						}
-						
+
 						// If we can't find it, return the worktree directory itself
 						// (this is what git rev-parse --show-toplevel returns for worktrees)
 						return current, nil
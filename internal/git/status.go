@@ -1,7 +1,9 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,7 +13,7 @@ import (
 // IsWorktreeClean checks if a worktree has uncommitted changes.
 // Returns (isClean, statusMessage).
 func IsWorktreeClean(worktreePath string) (bool, string) {
-	repo, err := OpenRepo(worktreePath)
+	repo, err := OpenRepo(context.Background(), worktreePath)
 	if err != nil {
 		return false, fmt.Sprintf("failed to open repository: %v", err)
 	}
@@ -62,6 +64,62 @@ func IsWorktreeClean(worktreePath string) (bool, string) {
 	return false, strings.Join(parts, ", ")
 }
 
+// Status is a structured breakdown of a worktree's dirty state, bucketed by
+// go-git's per-file status codes.
+type Status struct {
+	Modified         []string `json:"modified,omitempty"`
+	Added            []string `json:"added,omitempty"`
+	Deleted          []string `json:"deleted,omitempty"`
+	Untracked        []string `json:"untracked,omitempty"`
+	Conflicted       []string `json:"conflicted,omitempty"`
+	HasUnmergedPaths bool     `json:"has_unmerged_paths"`
+}
+
+// WorktreeStatus opens path as a git worktree and buckets its dirty files by
+// status code, including unmerged paths (go-git's UpdatedButUnmerged code)
+// left behind by a conflicted merge or rebase.
+func WorktreeStatus(path string) (*Status, error) {
+	repo, err := OpenRepo(context.Background(), path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	fileStatuses, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check status: %w", err)
+	}
+
+	status := &Status{}
+	for file, fileStatus := range fileStatuses {
+		switch {
+		case fileStatus.Staging == git.UpdatedButUnmerged || fileStatus.Worktree == git.UpdatedButUnmerged:
+			status.Conflicted = append(status.Conflicted, file)
+			status.HasUnmergedPaths = true
+		case fileStatus.Staging == git.Untracked || fileStatus.Worktree == git.Untracked:
+			status.Untracked = append(status.Untracked, file)
+		case fileStatus.Staging == git.Added:
+			status.Added = append(status.Added, file)
+		case fileStatus.Staging == git.Deleted || fileStatus.Worktree == git.Deleted:
+			status.Deleted = append(status.Deleted, file)
+		case fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified:
+			status.Modified = append(status.Modified, file)
+		}
+	}
+
+	sort.Strings(status.Modified)
+	sort.Strings(status.Added)
+	sort.Strings(status.Deleted)
+	sort.Strings(status.Untracked)
+	sort.Strings(status.Conflicted)
+
+	return status, nil
+}
+
 // GetStatusSummary returns a brief git status summary.
 func GetStatusSummary(repoRoot string) (string, error) {
 	isClean, status := IsWorktreeClean(repoRoot)
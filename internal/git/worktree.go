@@ -1,11 +1,16 @@
 package git
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 )
@@ -22,7 +27,7 @@ func ListWorktrees(repoRoot string) ([]Worktree, error) {
 	var worktrees []Worktree
 
 	// Add the main worktree
-	repo, err := OpenRepo(repoRoot)
+	repo, err := OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -41,8 +46,8 @@ func ListWorktrees(repoRoot string) ([]Worktree, error) {
 				if !entry.IsDir() {
 					continue
 				}
-				wtPath := filepath.Join(worktreesDir, entry.Name())
-				wt, err := parseWorktreeDir(wtPath, repoRoot)
+				wtDir := filepath.Join(worktreesDir, entry.Name())
+				wt, err := resolveWorktree(wtDir)
 				if err == nil {
 					worktrees = append(worktrees, wt)
 				}
@@ -68,60 +73,55 @@ func getMainWorktree(repo *Repo, repoRoot string) (Worktree, error) {
 	return wt, nil
 }
 
-// parseWorktreeDir parses a worktree directory in .git/worktrees.
-func parseWorktreeDir(worktreeDir, repoRoot string) (Worktree, error) {
-	wt := Worktree{}
-
-	// Read gitdir file to get the worktree .git path
-	gitdirFile := filepath.Join(worktreeDir, "gitdir")
+// resolveWorktree reads the gitdir file for a .git/worktrees/<id> registry
+// entry and resolves its branch/commit by opening the worktree itself
+// through go-git, rather than hand-parsing its raw HEAD and ref files.
+func resolveWorktree(registryDir string) (Worktree, error) {
+	gitdirFile := filepath.Join(registryDir, "gitdir")
 	data, err := os.ReadFile(gitdirFile)
 	if err != nil {
-		return wt, err
+		return Worktree{}, err
 	}
-	gitdirPath := strings.TrimSpace(string(data))
-	
-	// The gitdir file contains the path to the worktree's .git directory
-	// The worktree path is the parent directory of that .git directory
-	wt.Path = filepath.Dir(gitdirPath)
+	// The gitdir file holds the path to the worktree's own .git directory;
+	// the worktree itself is that directory's parent.
+	wtPath := filepath.Dir(strings.TrimSpace(string(data)))
+	wt := Worktree{Path: wtPath}
 
-	// Read HEAD file to get commit/branch
-	headFile := filepath.Join(worktreeDir, "HEAD")
-	data, err = os.ReadFile(headFile)
+	repo, err := OpenRepo(context.Background(), wtPath)
 	if err != nil {
-		return wt, err
-	}
-	headRef := strings.TrimSpace(string(data))
-
-	if strings.HasPrefix(headRef, "ref: refs/heads/") {
-		wt.Branch = strings.TrimPrefix(headRef, "ref: refs/heads/")
-		// Get commit from branch ref in main repo
-		refPath := filepath.Join(repoRoot, ".git", headRef[5:]) // Skip "ref: "
-		if data, err := os.ReadFile(refPath); err == nil {
-			commit := strings.TrimSpace(string(data))
-			if len(commit) > 8 {
-				wt.Commit = commit[:8]
-			} else {
-				wt.Commit = commit
-			}
-		}
-	} else {
-		// Detached HEAD - commit hash is in the HEAD file
-		commit := strings.TrimSpace(string(data))
-		if len(commit) > 8 {
-			wt.Commit = commit[:8]
-		} else {
-			wt.Commit = commit
-		}
+		// Registered but not yet (or no longer) a valid repository; report
+		// the path so callers can still see it exists.
+		return wt, nil
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return wt, nil
+	}
+	wt.Commit = head.Hash().String()[:8]
+	if head.Name().IsBranch() {
+		wt.Branch = head.Name().Short()
 	}
 
 	return wt, nil
 }
 
-// AddWorktree creates a new worktree.
-// If createBranch is true, creates a new branch from baseBranch (or HEAD if baseBranch is empty).
-// If createBranch is false, checks out the existing branch.
-func AddWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string) error { //nolint:gocyclo // Complex worktree creation logic
-	repo, err := OpenRepo(repoRoot)
+// AddWorktree creates a new worktree at path.
+//
+// Unlike a plain `git worktree add`, the new worktree is its own repository
+// (via git.PlainInit) rather than a linked checkout sharing the main repo's
+// refs — go-git has no public API for linked-worktree ref sharing. It shares
+// the main repository's object database through objects/info/alternates, the
+// same mechanism the git CLI itself honors, so creating a worktree doesn't
+// duplicate every blob, tree, and commit onto disk. The worktree is still
+// registered under the main repo's .git/worktrees/<id>, so ListWorktrees,
+// RemoveWorktree, and PruneWorktrees can find it without scanning for it.
+//
+// If createBranch is true, creates a new branch from baseBranch (or HEAD if
+// baseBranch is empty). If createBranch is false, checks out the existing
+// branch.
+func AddWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string) error {
+	repo, err := OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -131,101 +131,130 @@ func AddWorktree(repoRoot, path, branch string, createBranch bool, baseBranch st
 		return fmt.Errorf("failed to resolve path: %w", err)
 	}
 
-	// Create the directory
 	if err := os.MkdirAll(absPath, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	var baseHash plumbing.Hash
-	if createBranch {
-		if baseBranch == "" {
-			head, err := repo.Head()
-			if err != nil {
-				return fmt.Errorf("failed to get HEAD: %w", err)
-			}
-			baseHash = head.Hash()
-		} else {
-			ref, err := repo.Reference(plumbing.NewBranchReferenceName(baseBranch), true)
-			if err != nil {
-				return fmt.Errorf("base branch %s not found: %w", baseBranch, err)
-			}
-			baseHash = ref.Hash()
-		}
-	} else {
-		ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
-		if err != nil {
-			return fmt.Errorf("branch %s not found: %w", branch, err)
-		}
-		baseHash = ref.Hash()
+	baseHash, err := resolveWorktreeBase(repo, branch, createBranch, baseBranch)
+	if err != nil {
+		return err
 	}
 
-	// Create branch if needed
+	refName := plumbing.NewBranchReferenceName(branch)
 	if createBranch {
-		refName := plumbing.NewBranchReferenceName(branch)
-		ref := plumbing.NewHashReference(refName, baseHash)
-		if err := repo.Storer.SetReference(ref); err != nil {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(refName, baseHash)); err != nil {
 			return fmt.Errorf("failed to create branch: %w", err)
 		}
 	}
 
-	// Create worktree directory structure
-	worktreeID := generateWorktreeID()
-	worktreeDir := filepath.Join(repoRoot, ".git", "worktrees", worktreeID)
-	if err := os.MkdirAll(worktreeDir, 0755); err != nil {
-		return fmt.Errorf("failed to create worktree directory: %w", err)
+	worktreeRepo, err := initWorktreeRepo(absPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize worktree repository: %w", err)
 	}
 
-	// Write gitdir file (points to worktree path)
-	gitdirFile := filepath.Join(worktreeDir, "gitdir")
-	if err := os.WriteFile(gitdirFile, []byte(absPath+"\n"), 0644); err != nil { //nolint:gosec // Git directory file needs to be readable
-		return fmt.Errorf("failed to write gitdir: %w", err)
+	if err := linkObjectStore(absPath, repoRoot); err != nil {
+		return fmt.Errorf("failed to share object store: %w", err)
 	}
 
-	// Write HEAD file
-	headFile := filepath.Join(worktreeDir, "HEAD")
-	var headContent string
-	if createBranch || !createBranch {
-		headContent = fmt.Sprintf("ref: refs/heads/%s\n", branch)
-	} else {
-		headContent = baseHash.String() + "\n"
+	if err := worktreeRepo.Storer.SetReference(plumbing.NewHashReference(refName, baseHash)); err != nil {
+		return fmt.Errorf("failed to set branch in worktree: %w", err)
 	}
-	if err := os.WriteFile(headFile, []byte(headContent), 0644); err != nil { //nolint:gosec // Git HEAD file needs to be readable
-		return fmt.Errorf("failed to write HEAD: %w", err)
+
+	worktree, err := worktreeRepo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
 	}
 
-	// Create .git file in worktree pointing to worktree gitdir
-	worktreeGitFile := filepath.Join(absPath, ".git")
-	gitdirContent := fmt.Sprintf("gitdir: %s\n", worktreeDir)
-	if err := os.WriteFile(worktreeGitFile, []byte(gitdirContent), 0644); err != nil { //nolint:gosec // Git .git file needs to be readable
-		return fmt.Errorf("failed to write .git file: %w", err)
+	if err := worktree.Checkout(&git.CheckoutOptions{Branch: refName, Force: true}); err != nil {
+		return fmt.Errorf("failed to checkout branch: %w", err)
 	}
 
-	// Clone the repository to the worktree location using go-git
-	worktreeRepo, err := git.PlainClone(absPath, false, &git.CloneOptions{
-		URL: repoRoot,
-	})
-	if err != nil {
-		// If clone fails, try opening existing
-		worktreeRepo, err = git.PlainOpen(absPath)
+	if err := registerWorktree(repoRoot, absPath, branch); err != nil {
+		return fmt.Errorf("failed to register worktree: %w", err)
+	}
+
+	return nil
+}
+
+// resolveWorktreeBase resolves the commit a new worktree's branch should
+// point at: baseBranch (or HEAD) when creating a new branch, or the existing
+// branch's current tip otherwise.
+func resolveWorktreeBase(repo *Repo, branch string, createBranch bool, baseBranch string) (plumbing.Hash, error) {
+	if !createBranch {
+		ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
 		if err != nil {
-			return fmt.Errorf("failed to setup worktree repository: %w", err)
+			return plumbing.ZeroHash, fmt.Errorf("branch %s not found: %w", branch, err)
 		}
+		return ref.Hash(), nil
 	}
 
-	// Checkout the branch
-	worktree, err := worktreeRepo.Worktree()
+	if baseBranch == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		return head.Hash(), nil
+	}
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(baseBranch), true)
 	if err != nil {
-		return fmt.Errorf("failed to get worktree: %w", err)
+		return plumbing.ZeroHash, fmt.Errorf("base branch %s not found: %w", baseBranch, err)
 	}
+	return ref.Hash(), nil
+}
 
-	checkoutOpts := git.CheckoutOptions{
-		Branch: plumbing.NewBranchReferenceName(branch),
-		Hash:   baseHash,
-		Force:  true,
+// initWorktreeRepo is git.PlainInit, but with the alternates-aware storage
+// newAlternatesAwareStorage builds instead of go-git's default chrooted
+// storage, so the worktree can actually resolve objects shared via
+// linkObjectStore rather than duplicating them.
+func initWorktreeRepo(path string) (*git.Repository, error) {
+	wt := osfs.New(path)
+	dot, err := wt.Chroot(git.GitDirName)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := worktree.Checkout(&checkoutOpts); err != nil {
-		return fmt.Errorf("failed to checkout branch: %w", err)
+	return git.Init(newAlternatesAwareStorage(dot), wt)
+}
+
+// linkObjectStore points the worktree's object database at repoRoot's via
+// objects/info/alternates, so the worktree can resolve every object already
+// in the main repository without git.PlainClone's full object copy. Go-git's
+// filesystem object storage reads this file the same way the git CLI does.
+func linkObjectStore(worktreePath, repoRoot string) error {
+	mainObjects, err := filepath.Abs(filepath.Join(repoRoot, ".git", "objects"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve main object store: %w", err)
+	}
+
+	infoDir := filepath.Join(worktreePath, ".git", "objects", "info")
+	if err := os.MkdirAll(infoDir, 0755); err != nil {
+		return fmt.Errorf("failed to create objects/info: %w", err)
+	}
+
+	alternatesFile := filepath.Join(infoDir, "alternates")
+	if err := os.WriteFile(alternatesFile, []byte(mainObjects+"\n"), 0644); err != nil { //nolint:gosec // Alternates file needs to be readable
+		return fmt.Errorf("failed to write alternates: %w", err)
+	}
+
+	return nil
+}
+
+// registerWorktree records worktreePath under repoRoot's .git/worktrees/<id>,
+// the same bookkeeping location `git worktree list` uses, so ListWorktrees,
+// RemoveWorktree, and PruneWorktrees can discover it without scanning the
+// filesystem for every directory that might be a workspace.
+func registerWorktree(repoRoot, worktreePath, branch string) error {
+	worktreeID := generateWorktreeID(branch)
+	registryDir := filepath.Join(repoRoot, ".git", "worktrees", worktreeID)
+	if err := os.MkdirAll(registryDir, 0755); err != nil {
+		return fmt.Errorf("failed to create worktree registry directory: %w", err)
+	}
+
+	gitdirFile := filepath.Join(registryDir, "gitdir")
+	worktreeGitDir := filepath.Join(worktreePath, ".git")
+	if err := os.WriteFile(gitdirFile, []byte(worktreeGitDir+"\n"), 0644); err != nil { //nolint:gosec // Git directory file needs to be readable
+		return fmt.Errorf("failed to write gitdir: %w", err)
 	}
 
 	return nil
@@ -238,7 +267,6 @@ func RemoveWorktree(repoRoot, path string, force bool) error {
 		return fmt.Errorf("failed to resolve path: %w", err)
 	}
 
-	// Find the worktree entry in .git/worktrees
 	worktreesDir := filepath.Join(repoRoot, ".git", "worktrees")
 	entries, err := os.ReadDir(worktreesDir)
 	if err != nil {
@@ -249,46 +277,107 @@ func RemoveWorktree(repoRoot, path string, force bool) error {
 		if !entry.IsDir() {
 			continue
 		}
-		wtDir := filepath.Join(worktreesDir, entry.Name())
-		gitdirFile := filepath.Join(wtDir, "gitdir")
+		registryDir := filepath.Join(worktreesDir, entry.Name())
+		gitdirFile := filepath.Join(registryDir, "gitdir")
 		data, err := os.ReadFile(gitdirFile)
 		if err != nil {
 			continue
 		}
-		wtPath := strings.TrimSpace(string(data))
-		if wtPath == absPath {
-			// Found the worktree - remove it
-			if force {
-				// Remove the worktree directory
-				if err := os.RemoveAll(absPath); err != nil {
-					return fmt.Errorf("failed to remove worktree directory: %w", err)
-				}
-			} else {
-				// Check if worktree is clean
-				isClean, _ := IsWorktreeClean(absPath)
-				if !isClean {
-					return fmt.Errorf("worktree has uncommitted changes")
-				}
-				if err := os.RemoveAll(absPath); err != nil {
-					return fmt.Errorf("failed to remove worktree directory: %w", err)
-				}
-			}
-			// Remove the worktree entry
-			if err := os.RemoveAll(wtDir); err != nil {
-				return fmt.Errorf("failed to remove worktree entry: %w", err)
+		wtPath := filepath.Dir(strings.TrimSpace(string(data)))
+		if wtPath != absPath {
+			continue
+		}
+
+		if !force {
+			isClean, _ := IsWorktreeClean(absPath)
+			if !isClean {
+				return fmt.Errorf("worktree has uncommitted changes")
 			}
-			return nil
 		}
+
+		if err := os.RemoveAll(absPath); err != nil {
+			return fmt.Errorf("failed to remove worktree directory: %w", err)
+		}
+		if err := os.RemoveAll(registryDir); err != nil {
+			return fmt.Errorf("failed to remove worktree entry: %w", err)
+		}
+		return nil
 	}
 
 	return fmt.Errorf("worktree not found at %s", path)
 }
 
-// generateWorktreeID generates a unique worktree ID.
-func generateWorktreeID() string {
-	// Simple implementation - in practice git uses a hash
-	// For now, use a timestamp-based approach
-	return fmt.Sprintf("worktree-%d", os.Getpid())
+// PruneWorktrees removes stale entries under .git/worktrees: ones whose
+// gitdir file points at a directory that no longer exists, e.g. because the
+// worktree was deleted by hand instead of through RemoveWorktree. It returns
+// the names of the entries it removed.
+func PruneWorktrees(repoRoot string) ([]string, error) {
+	worktreesDir := filepath.Join(repoRoot, ".git", "worktrees")
+	entries, err := os.ReadDir(worktreesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read worktrees directory: %w", err)
+	}
+
+	var pruned []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		wtDir := filepath.Join(worktreesDir, entry.Name())
+		gitdirFile := filepath.Join(wtDir, "gitdir")
+		data, err := os.ReadFile(gitdirFile)
+		if err != nil {
+			continue
+		}
+		wtPath := filepath.Dir(strings.TrimSpace(string(data)))
+		if _, err := os.Stat(wtPath); err == nil {
+			continue // worktree still exists, nothing to prune
+		}
+		if err := os.RemoveAll(wtDir); err != nil {
+			return pruned, fmt.Errorf("failed to remove stale worktree entry %s: %w", entry.Name(), err)
+		}
+		pruned = append(pruned, entry.Name())
+	}
+
+	return pruned, nil
+}
+
+var worktreeIDInvalid = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// generateWorktreeID generates a worktree registry ID from a short slug of
+// branch plus a random suffix, so concurrent worktree creation (e.g. several
+// agents creating workspaces at once) can't collide the way a PID-based ID
+// would once two creations land in different processes that happen to share
+// a PID (containers, PID reuse) or race within the same process.
+func generateWorktreeID(branch string) string {
+	suffix := randomHex(4)
+
+	slug := worktreeIDInvalid.ReplaceAllString(branch, "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > 24 {
+		slug = slug[:24]
+	}
+	if slug == "" {
+		return suffix
+	}
+
+	return fmt.Sprintf("%s-%s", slug, suffix)
+}
+
+// randomHex returns n random bytes hex-encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand.Read failing means the OS's entropy source is
+		// unavailable, which is far outside what AddWorktree can recover
+		// from; fall back to a fixed suffix rather than failing worktree
+		// creation over it.
+		return strings.Repeat("0", n*2)
+	}
+	return hex.EncodeToString(b)
 }
 
 // GetWorktreePath returns the absolute path of a worktree.
@@ -0,0 +1,182 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// initTestRepo creates a bare-bones repository with a single commit on
+// "main" at t.TempDir() and returns its root.
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	commit, err := wt.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), commit)); err != nil {
+		t.Fatalf("failed to set main ref: %v", err)
+	}
+	head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))
+	if err := repo.Storer.SetReference(head); err != nil {
+		t.Fatalf("failed to set HEAD: %v", err)
+	}
+
+	return root
+}
+
+func TestAddWorktreeSharesObjectStore(t *testing.T) {
+	repoRoot := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "feature")
+
+	if err := AddWorktree(repoRoot, wtPath, "feature", true, "main"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(wtPath, "README.md")); err != nil {
+		t.Errorf("expected README.md to be checked out in the worktree: %v", err)
+	}
+
+	alternates, err := os.ReadFile(filepath.Join(wtPath, ".git", "objects", "info", "alternates"))
+	if err != nil {
+		t.Fatalf("failed to read alternates file: %v", err)
+	}
+	mainObjects, _ := filepath.Abs(filepath.Join(repoRoot, ".git", "objects"))
+	if got := string(alternates); got != mainObjects+"\n" {
+		t.Errorf("alternates = %q, want %q", got, mainObjects+"\n")
+	}
+
+	if info, err := os.Stat(filepath.Join(wtPath, ".git", "objects", "pack")); err == nil && info.IsDir() {
+		entries, _ := os.ReadDir(filepath.Join(wtPath, ".git", "objects", "pack"))
+		if len(entries) != 0 {
+			t.Errorf("expected the worktree's own object store to stay empty, found %v", entries)
+		}
+	}
+}
+
+func TestAddWorktreeRegistersAndListsWorktree(t *testing.T) {
+	repoRoot := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "feature")
+
+	if err := AddWorktree(repoRoot, wtPath, "feature", true, "main"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	worktrees, err := ListWorktrees(repoRoot)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+
+	var found *Worktree
+	for i := range worktrees {
+		if worktrees[i].Path == wtPath {
+			found = &worktrees[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected ListWorktrees to include %s, got %v", wtPath, worktrees)
+	}
+	if found.Branch != "feature" {
+		t.Errorf("expected branch 'feature', got %q", found.Branch)
+	}
+}
+
+func TestRemoveWorktree(t *testing.T) {
+	repoRoot := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "feature")
+
+	if err := AddWorktree(repoRoot, wtPath, "feature", true, "main"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	if err := RemoveWorktree(repoRoot, wtPath, false); err != nil {
+		t.Fatalf("RemoveWorktree failed: %v", err)
+	}
+
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Errorf("expected worktree directory to be removed, stat err = %v", err)
+	}
+
+	worktrees, err := ListWorktrees(repoRoot)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	for _, wt := range worktrees {
+		if wt.Path == wtPath {
+			t.Errorf("expected %s to no longer be listed after removal", wtPath)
+		}
+	}
+}
+
+func TestPruneWorktreesRemovesStaleEntries(t *testing.T) {
+	repoRoot := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "feature")
+
+	if err := AddWorktree(repoRoot, wtPath, "feature", true, "main"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	// Simulate the worktree being deleted by hand instead of via RemoveWorktree.
+	if err := os.RemoveAll(wtPath); err != nil {
+		t.Fatalf("failed to remove worktree directory: %v", err)
+	}
+
+	pruned, err := PruneWorktrees(repoRoot)
+	if err != nil {
+		t.Fatalf("PruneWorktrees failed: %v", err)
+	}
+	if len(pruned) != 1 {
+		t.Fatalf("expected 1 pruned entry, got %v", pruned)
+	}
+
+	worktrees, err := ListWorktrees(repoRoot)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	for _, wt := range worktrees {
+		if wt.Path == wtPath {
+			t.Errorf("expected stale worktree entry to be pruned")
+		}
+	}
+}
+
+func TestGenerateWorktreeIDIsUniqueAndSluggedFromBranch(t *testing.T) {
+	id1 := generateWorktreeID("feature/my-thing")
+	id2 := generateWorktreeID("feature/my-thing")
+
+	if id1 == id2 {
+		t.Errorf("expected two IDs for the same branch to differ, got %q twice", id1)
+	}
+	if !worktreeIDInvalid.MatchString("feature/my-thing") {
+		t.Fatalf("test assumes '/' needs slugging")
+	}
+	if !strings.HasPrefix(id1, "feature-my-thing-") {
+		t.Errorf("expected ID slugged from the branch, got %q", id1)
+	}
+}
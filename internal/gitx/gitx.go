@@ -0,0 +1,254 @@
+// Package gitx provides a typed, in-process git API built on go-git, so
+// hot paths like hook context injection don't have to fork a `git`
+// subprocess per call. It wraps internal/git's lower-level Repo with the
+// specific operations callers like internal/hook need, plus a shell
+// fallback for repository features go-git doesn't support (LFS, partial
+// clone).
+package gitx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// Repo wraps internal/git.Repo with typed, go-git–backed operations.
+type Repo struct {
+	*git.Repo
+	root string
+}
+
+// OpenRepo opens a repository at path, discovering the repository root if
+// path is inside a worktree.
+func OpenRepo(path string) (*Repo, error) {
+	repo, err := git.OpenRepoWithDiscover(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+	return &Repo{Repo: repo, root: path}, nil
+}
+
+// Branch returns the current branch name, or "" for a detached HEAD or an
+// empty repository.
+func (r *Repo) Branch() (string, error) {
+	if usesShellFallback(r.root) {
+		return shellBranch(r.root)
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return "", nil //nolint:nilerr // Detached HEAD / no commits yet is not an error
+	}
+	if !head.Name().IsBranch() {
+		return "", nil
+	}
+	return head.Name().Short(), nil
+}
+
+// StatusSummary reports whether the working tree is clean and a short
+// human-readable summary of what's changed if not.
+func (r *Repo) StatusSummary() (isClean bool, summary string) {
+	if usesShellFallback(r.root) {
+		return shellStatusSummary(r.root)
+	}
+	return git.IsWorktreeClean(r.root)
+}
+
+// BranchInfo describes a local branch and, when it has a worktree checked
+// out, that worktree's cleanliness.
+type BranchInfo struct {
+	Name    string
+	Status  string // "clean", a change summary, or "unknown" if no worktree is checked out for this branch
+	IsClean bool
+}
+
+// ListBranches lists local branches. Cleanliness is computed via
+// Worktree.Status() for any branch that has a worktree checked out
+// (including the main working directory); branches with no worktree report
+// "unknown" since there's no working tree to inspect without checking one
+// out.
+func (r *Repo) ListBranches() ([]BranchInfo, error) {
+	if usesShellFallback(r.root) {
+		return shellListBranches(r.root)
+	}
+
+	repoRoot := r.Root()
+
+	worktreeByBranch := make(map[string]string)
+	if worktrees, err := git.ListWorktrees(repoRoot); err == nil {
+		for _, wt := range worktrees {
+			if wt.Branch != "" {
+				worktreeByBranch[wt.Branch] = wt.Path
+			}
+		}
+	}
+
+	refs, err := r.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []BranchInfo
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		info := BranchInfo{Name: name, Status: "unknown"}
+		if path, ok := worktreeByBranch[name]; ok {
+			isClean, status := git.IsWorktreeClean(path)
+			info.IsClean = isClean
+			info.Status = status
+		}
+		branches = append(branches, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return branches, nil
+}
+
+// WorktreeOptions controls AddWorktree's behavior.
+type WorktreeOptions struct {
+	// CreateBranch creates a new branch at Base before adding the worktree.
+	CreateBranch bool
+	// Base is the branch/commit the new branch (or checkout, if
+	// CreateBranch is false) starts from.
+	Base string
+}
+
+// AddWorktree creates a worktree at path checked out to branch.
+func (r *Repo) AddWorktree(path, branch string, opts WorktreeOptions) error {
+	return git.AddWorktree(r.Root(), path, branch, opts.CreateBranch, opts.Base)
+}
+
+// RemoveWorktree removes the worktree at path.
+func (r *Repo) RemoveWorktree(path string) error {
+	isClean, _ := git.IsWorktreeClean(path)
+	return git.RemoveWorktree(r.Root(), path, !isClean)
+}
+
+// Checkout checks out opts in the repository's worktree.
+func (r *Repo) Checkout(opts gogit.CheckoutOptions) error {
+	worktree, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	return worktree.Checkout(&opts)
+}
+
+// Reset resets the repository's worktree to commit using mode.
+func (r *Repo) Reset(mode gogit.ResetMode, commit plumbing.Hash) error {
+	worktree, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	return worktree.Reset(&gogit.ResetOptions{Mode: mode, Commit: commit})
+}
+
+// usesShellFallback reports whether repo-at-root uses a feature go-git
+// doesn't support (Git LFS or a partial clone), in which case callers
+// should shell out to the system `git` instead. Can be forced on for
+// testing/diagnosis via AGENTCTL_GIT_SHELL_FALLBACK=1.
+func usesShellFallback(root string) bool {
+	if v, err := strconv.ParseBool(os.Getenv("AGENTCTL_GIT_SHELL_FALLBACK")); err == nil {
+		return v
+	}
+
+	repoRoot, err := git.OpenRepoWithDiscover(context.Background(), root)
+	resolvedRoot := root
+	if err == nil {
+		resolvedRoot = repoRoot.Root()
+	}
+
+	if _, err := os.Stat(filepath.Join(resolvedRoot, ".git", "lfs")); err == nil {
+		return true
+	}
+
+	configPath := filepath.Join(resolvedRoot, ".git", "config")
+	data, err := os.ReadFile(configPath) //nolint:gosec // Path is derived from the repo root
+	if err == nil && strings.Contains(string(data), "partialclonefilter") {
+		return true
+	}
+
+	return false
+}
+
+func shellBranch(root string) (string, error) {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", nil //nolint:nilerr // No commits yet / detached HEAD is not an error
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+func shellStatusSummary(root string) (bool, string) {
+	out, err := exec.Command("git", "-C", root, "status", "--porcelain").Output()
+	if err != nil {
+		return false, fmt.Sprintf("failed to check status: %v", err)
+	}
+
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return true, "clean"
+	}
+
+	var staged, modified, untracked int
+	for _, line := range strings.Split(trimmed, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '?' {
+			staged++
+		}
+		if line[1] != ' ' && line[1] != '?' {
+			modified++
+		}
+		if strings.HasPrefix(line, "??") {
+			untracked++
+		}
+	}
+
+	var parts []string
+	if staged > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", staged))
+	}
+	if modified > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", modified))
+	}
+	if untracked > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", untracked))
+	}
+	if len(parts) == 0 {
+		return true, "clean"
+	}
+	return false, strings.Join(parts, ", ")
+}
+
+func shellListBranches(root string) ([]BranchInfo, error) {
+	out, err := exec.Command("git", "-C", root, "branch", "--list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []BranchInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name := strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		if name == "" {
+			continue
+		}
+		branches = append(branches, BranchInfo{Name: name, Status: "unknown"})
+	}
+	return branches, nil
+}
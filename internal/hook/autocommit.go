@@ -2,45 +2,57 @@
 package hook
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/ryantking/agentctl/internal/git"
 )
 
-// PostEdit auto-commits changes if on a feature branch.
+// configuredHookConfig builds the effective HookConfig from environment
+// overrides, falling back to DefaultHookConfig. A full settings.json-backed
+// config loader can replace this once one exists; env vars keep batching
+// opt-in without a schema migration.
+func configuredHookConfig() HookConfig {
+	cfg := DefaultHookConfig()
+	if mode := os.Getenv("AGENTCTL_HOOK_MODE"); mode != "" {
+		cfg.Mode = Mode(mode)
+	}
+	if ms := os.Getenv("AGENTCTL_HOOK_DEBOUNCE_MS"); ms != "" {
+		if parsed, err := strconv.Atoi(ms); err == nil && parsed > 0 {
+			cfg.DebounceMS = parsed
+		}
+	}
+	return cfg
+}
+
+// PostEdit records an edit and, depending on the configured HookConfig.Mode,
+// either commits it immediately, schedules a debounced flush, or leaves it
+// queued for an explicit `agentctl hook flush` (session mode).
 // Reads file path from stdin JSON.
 func PostEdit(filePath string) error {
-	if filePath == "" {
-		return nil
-	}
-
-	repoRoot, err := git.GetRepoRoot()
-	if err != nil {
-		return nil // Not in a repo, skip
-	}
-
-	branch, err := git.GetCurrentBranch(repoRoot)
-	if err != nil || branch == "" {
-		return nil
-	}
-
-	if isMainBranch(branch) {
-		return nil // Skip on main/master
-	}
-
-	return gitAddAndCommit(repoRoot, filePath)
+	return postChange(filePath, "edit")
 }
 
-// PostWrite auto-commits new files if on a feature branch.
+// PostWrite records a new file and, depending on the configured
+// HookConfig.Mode, either commits it immediately, schedules a debounced
+// flush, or leaves it queued for an explicit `agentctl hook flush` (session
+// mode).
 // Reads file path from stdin JSON.
 func PostWrite(filePath string) error {
+	return postChange(filePath, "write")
+}
+
+func postChange(filePath, op string) error {
 	if filePath == "" {
 		return nil
 	}
 
-	repoRoot, err := git.GetRepoRoot()
+	repoRoot, err := git.GetRepoRoot(context.Background())
 	if err != nil {
 		return nil // Not in a repo, skip
 	}
@@ -54,7 +66,30 @@ func PostWrite(filePath string) error {
 		return nil // Skip on main/master
 	}
 
-	return gitAddAndCommitNewFile(repoRoot, filePath)
+	if err := PreCommit(repoRoot); err != nil {
+		return err
+	}
+
+	cfg := configuredHookConfig()
+	switch cfg.Mode {
+	case ModeDebounce, ModeSession:
+		if err := EnqueueChange(repoRoot, branch, PendingChange{
+			Path:      filePath,
+			Op:        op,
+			Timestamp: time.Now(),
+		}); err != nil {
+			return err
+		}
+		if cfg.Mode == ModeDebounce {
+			ScheduleFlush(repoRoot, branch, cfg)
+		}
+		return nil
+	default:
+		if op == "write" {
+			return gitAddAndCommitNewFile(repoRoot, filePath)
+		}
+		return gitAddAndCommit(repoRoot, filePath)
+	}
 }
 
 func isMainBranch(branch string) bool {
@@ -62,7 +97,7 @@ func isMainBranch(branch string) bool {
 }
 
 func gitAddAndCommit(repoRoot, filePath string) error {
-	repo, err := git.OpenRepo(repoRoot)
+	repo, err := git.OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -113,7 +148,7 @@ func gitAddAndCommit(repoRoot, filePath string) error {
 }
 
 func gitAddAndCommitNewFile(repoRoot, filePath string) error {
-	repo, err := git.OpenRepo(repoRoot)
+	repo, err := git.OpenRepo(context.Background(), repoRoot)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -0,0 +1,246 @@
+package hook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// Mode selects how PostEdit/PostWrite turn file changes into commits.
+type Mode string
+
+const (
+	// ModeImmediate commits every change as soon as it's made (current
+	// default behavior).
+	ModeImmediate Mode = "immediate"
+	// ModeDebounce batches changes and flushes them after DebounceMS of
+	// inactivity.
+	ModeDebounce Mode = "debounce"
+	// ModeSession batches changes for the whole session and only flushes
+	// when an explicit `agentctl hook flush` is run (e.g. from a
+	// SessionEnd/Stop hook).
+	ModeSession Mode = "session"
+)
+
+// HookConfig controls batching behavior for auto-commit hooks.
+type HookConfig struct { //nolint:revive // Stuttering is acceptable for exported config types
+	Mode       Mode
+	DebounceMS int
+}
+
+// DefaultHookConfig returns the default hook configuration: immediate
+// commits, matching the pre-batching behavior.
+func DefaultHookConfig() HookConfig {
+	return HookConfig{Mode: ModeImmediate, DebounceMS: 2000}
+}
+
+// PendingChange is a single queued file change awaiting a batched commit.
+type PendingChange struct {
+	Path      string    `json:"path"`
+	Op        string    `json:"op"` // "edit" or "write"
+	Timestamp time.Time `json:"timestamp"`
+	Tool      string    `json:"tool,omitempty"`
+}
+
+// pendingQueuePath returns the per-branch queue file path for a repo.
+func pendingQueuePath(repoRoot, branch string) string {
+	safeBranch := strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(repoRoot, ".git", "agentctl", fmt.Sprintf("pending-%s.jsonl", safeBranch))
+}
+
+// EnqueueChange appends a pending change to the per-branch queue file.
+func EnqueueChange(repoRoot, branch string, change PendingChange) error {
+	path := pendingQueuePath(repoRoot, branch)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // Queue directory needs to be readable
+		return fmt.Errorf("failed to create pending queue directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644) //nolint:gosec // Queue file needs to be readable
+	if err != nil {
+		return fmt.Errorf("failed to open pending queue: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// readPending reads and deduplicates queued changes for a branch, keeping
+// only the most recent entry per path.
+func readPending(repoRoot, branch string) ([]PendingChange, string, error) {
+	path := pendingQueuePath(repoRoot, branch)
+	file, err := os.Open(path) //nolint:gosec // Queue path is derived from repo root and branch, not user input
+	if os.IsNotExist(err) {
+		return nil, path, nil
+	}
+	if err != nil {
+		return nil, path, err
+	}
+	defer func() { _ = file.Close() }()
+
+	byPath := make(map[string]PendingChange)
+	var order []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var change PendingChange
+		if err := json.Unmarshal([]byte(line), &change); err != nil {
+			continue
+		}
+		if _, seen := byPath[change.Path]; !seen {
+			order = append(order, change.Path)
+		}
+		byPath[change.Path] = change
+	}
+
+	changes := make([]PendingChange, 0, len(order))
+	for _, p := range order {
+		changes = append(changes, byPath[p])
+	}
+	return changes, path, scanner.Err()
+}
+
+// FlushPending stages every queued change for branch, skipping no-op
+// entries via go-git's Status diffing, and coalesces whatever remains into a
+// single commit summarizing the files and directories touched. It returns
+// the commit message, or "" if there was nothing to commit. The queue file
+// is removed once flushed.
+func FlushPending(repoRoot, branch string) (string, error) {
+	changes, queuePath, err := readPending(repoRoot, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pending queue: %w", err)
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	repo, err := git.OpenRepo(context.Background(), repoRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	var added []string
+	for _, change := range changes {
+		relPath, err := relativeToRoot(repoRoot, change.Path)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(repoRoot, relPath)); err != nil {
+			// File was deleted since being queued; go-git's worktree.Add
+			// also stages deletions, so attempt it anyway.
+		}
+		if _, err := worktree.Add(relPath); err != nil {
+			continue
+		}
+		added = append(added, relPath)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get status: %w", err)
+	}
+
+	// Only queued paths whose staged state actually differs from HEAD count
+	// toward the summary; no-op entries are left out.
+	dirs := make(map[string]bool)
+	staged := 0
+	for _, relPath := range added {
+		if fileStatus, ok := status[filepath.ToSlash(relPath)]; ok && fileStatus.Staging != gogit.Unmodified {
+			dirs[filepath.Dir(relPath)] = true
+			staged++
+		}
+	}
+
+	anyStaged := false
+	for _, fileStatus := range status {
+		if fileStatus.Staging != gogit.Unmodified {
+			anyStaged = true
+			break
+		}
+	}
+
+	if !anyStaged {
+		_ = os.Remove(queuePath)
+		return "", nil
+	}
+
+	msg := fmt.Sprintf("Update %d files across %d directories", staged, len(dirs))
+	if _, err := worktree.Commit(msg, &gogit.CommitOptions{}); err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	_ = os.Remove(queuePath)
+	return msg, nil
+}
+
+// relativeToRoot converts an absolute or cwd-relative path to one relative
+// to repoRoot, suitable for worktree.Add.
+func relativeToRoot(repoRoot, path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(repoRoot, absPath)
+}
+
+// debouncer tracks a single pending flush timer per (repoRoot, branch) pair
+// so that a burst of edits collapses into one flush after DebounceMS of
+// inactivity. Its timers live only as long as the process that called
+// ScheduleFlush; it is not a persistent daemon.
+type debouncer struct {
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+var defaultDebouncer = &debouncer{timers: make(map[string]*time.Timer)}
+
+// ScheduleFlush (re)starts the debounce timer for repoRoot/branch, flushing
+// the pending queue once DebounceMS elapses without a further call.
+func ScheduleFlush(repoRoot, branch string, cfg HookConfig) {
+	key := repoRoot + "\x00" + branch
+	delay := time.Duration(cfg.DebounceMS) * time.Millisecond
+
+	defaultDebouncer.mu.Lock()
+	defer defaultDebouncer.mu.Unlock()
+
+	if t, ok := defaultDebouncer.timers[key]; ok {
+		t.Stop()
+	}
+	defaultDebouncer.timers[key] = time.AfterFunc(delay, func() {
+		_, _ = FlushPending(repoRoot, branch)
+	})
+}
+
+// sortedDirs is a small helper kept for callers that want a stable,
+// human-readable directory list (e.g. for logging) rather than just a count.
+func sortedDirs(dirs map[string]bool) []string {
+	result := make([]string, 0, len(dirs))
+	for d := range dirs {
+		result = append(result, d)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,44 @@
+package hook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// maxConflictFilesInMessage caps how many conflicted paths are listed in a
+// notification body; large conflict sets get truncated with a count instead.
+const maxConflictFilesInMessage = 5
+
+// PreCommit checks repoRoot for unmerged paths left behind by a conflicted
+// merge or rebase. If any are found, it fires an error notification and
+// returns an error so callers (the auto-commit hooks) can skip committing
+// into a conflicted worktree. A failed or inconclusive status check is not
+// treated as a conflict.
+func PreCommit(repoRoot string) error {
+	status, err := git.WorktreeStatus(repoRoot)
+	if err != nil {
+		return nil
+	}
+
+	if !status.HasUnmergedPaths {
+		return nil
+	}
+
+	_ = NotifyError(conflictMessage(status.Conflicted))
+	return fmt.Errorf("worktree has %d unmerged path(s), skipping auto-commit", len(status.Conflicted))
+}
+
+func conflictMessage(conflicted []string) string {
+	shown := conflicted
+	if len(shown) > maxConflictFilesInMessage {
+		shown = shown[:maxConflictFilesInMessage]
+	}
+
+	msg := fmt.Sprintf("Workspace has %d unmerged path(s), skipping auto-commit:\n%s", len(conflicted), strings.Join(shown, "\n"))
+	if remaining := len(conflicted) - len(shown); remaining > 0 {
+		msg += fmt.Sprintf("\n...and %d more", remaining)
+	}
+	return msg
+}
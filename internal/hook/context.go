@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/ryantking/agentctl/internal/gitx"
 )
 
 // ContextInfo generates context information for injection into prompts.
@@ -101,85 +103,44 @@ func ContextInfo() (string, error) {
 }
 
 func getGitBranch(repoRoot string) string {
-	cmd := exec.Command("git", "-C", repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
+	repo, err := gitx.OpenRepo(repoRoot)
 	if err != nil {
 		return ""
 	}
-	branch := strings.TrimSpace(string(output))
-	if branch == "HEAD" {
+	branch, err := repo.Branch()
+	if err != nil {
 		return ""
 	}
 	return branch
 }
 
 func getGitStatusSummary(repoRoot string) string {
-	cmd := exec.Command("git", "-C", repoRoot, "status", "--porcelain")
-	output, err := cmd.Output()
+	repo, err := gitx.OpenRepo(repoRoot)
 	if err != nil {
 		return ""
 	}
-
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) == 1 && lines[0] == "" {
-		return "clean"
-	}
-
-	var staged, modified, untracked int
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		if len(line) < 2 {
-			continue
-		}
-		if line[0] != ' ' && line[0] != '?' {
-			staged++
-		}
-		if line[1] != ' ' && line[1] != '?' {
-			modified++
-		}
-		if strings.HasPrefix(line, "??") {
-			untracked++
-		}
-	}
-
-	var parts []string
-	if staged > 0 {
-		parts = append(parts, fmt.Sprintf("%d staged", staged))
-	}
-	if modified > 0 {
-		parts = append(parts, fmt.Sprintf("%d modified", modified))
-	}
-	if untracked > 0 {
-		parts = append(parts, fmt.Sprintf("%d untracked", untracked))
-	}
-
-	if len(parts) == 0 {
+	isClean, summary := repo.StatusSummary()
+	if isClean {
 		return "clean"
 	}
-	return strings.Join(parts, ", ")
+	return summary
 }
 
 func getAllGitBranches(repoRoot string) map[string]string {
 	branches := make(map[string]string)
-	cmd := exec.Command("git", "-C", repoRoot, "branch", "--list")
-	output, err := cmd.Output()
+
+	repo, err := gitx.OpenRepo(repoRoot)
 	if err != nil {
 		return branches
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "*") {
-			line = strings.TrimPrefix(line, "*")
-		}
-		branch := strings.TrimSpace(line)
-		if branch == "" {
-			continue
-		}
-		// Simplified: mark as unknown (checking cleanliness is expensive)
-		branches[branch] = "unknown"
+	branchInfos, err := repo.ListBranches()
+	if err != nil {
+		return branches
+	}
+
+	for _, info := range branchInfos {
+		branches[info.Name] = info.Status
 	}
 	return branches
 }
@@ -235,7 +196,7 @@ func getPRStatus(repoRoot string) map[string]interface{} {
 	result := map[string]interface{}{
 		"number": prData["number"],
 		"title":  prData["title"],
-		"url":     prData["url"],
+		"url":    prData["url"],
 	}
 
 	if review, ok := prData["reviewDecision"].(string); ok {
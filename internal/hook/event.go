@@ -0,0 +1,150 @@
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EventName identifies one of the hook events Claude Code can invoke.
+type EventName string
+
+const (
+	EventPreToolUse       EventName = "PreToolUse"
+	EventPostToolUse      EventName = "PostToolUse"
+	EventNotification     EventName = "Notification"
+	EventStop             EventName = "Stop"
+	EventSubagentStop     EventName = "SubagentStop"
+	EventUserPromptSubmit EventName = "UserPromptSubmit"
+)
+
+// ErrUnknownHookEvent is returned by Decode when hook_event_name doesn't
+// match one of the known EventName values.
+var ErrUnknownHookEvent = fmt.Errorf("unknown hook_event_name")
+
+// Event is the common interface implemented by every typed hook event.
+// Name reports which concrete type the Event is, for use in a type switch
+// without a second json.Unmarshal.
+type Event interface {
+	Name() EventName
+}
+
+// base carries the fields present on every hook event.
+type base struct {
+	SessionID      string    `json:"session_id"`
+	TranscriptPath string    `json:"transcript_path"`
+	CWD            string    `json:"cwd"`
+	HookEventName  EventName `json:"hook_event_name"`
+}
+
+// PreToolUseEvent is sent before a tool call executes. A handler's
+// HookDecision determines whether the call is allowed to proceed.
+type PreToolUseEvent struct {
+	base
+	ToolName  string                 `json:"tool_name"`
+	ToolInput map[string]interface{} `json:"tool_input"`
+}
+
+func (e PreToolUseEvent) Name() EventName { return EventPreToolUse }
+
+// PostToolUseEvent is sent after a tool call executes, with its response.
+type PostToolUseEvent struct {
+	base
+	ToolName     string                 `json:"tool_name"`
+	ToolInput    map[string]interface{} `json:"tool_input"`
+	ToolResponse map[string]interface{} `json:"tool_response"`
+}
+
+func (e PostToolUseEvent) Name() EventName { return EventPostToolUse }
+
+// NotificationEvent is sent when Claude Code wants to surface a message to
+// the user (e.g. waiting on permission, idle timeout).
+type NotificationEvent struct {
+	base
+	Message string `json:"message"`
+}
+
+func (e NotificationEvent) Name() EventName { return EventNotification }
+
+// StopEvent is sent when the main agent loop finishes responding.
+type StopEvent struct {
+	base
+	StopHookActive bool `json:"stop_hook_active"`
+}
+
+func (e StopEvent) Name() EventName { return EventStop }
+
+// SubagentStopEvent is sent when a subagent (Task tool) finishes responding.
+type SubagentStopEvent struct {
+	base
+	StopHookActive bool `json:"stop_hook_active"`
+}
+
+func (e SubagentStopEvent) Name() EventName { return EventSubagentStop }
+
+// UserPromptSubmitEvent is sent when the user submits a prompt, before
+// Claude processes it.
+type UserPromptSubmitEvent struct {
+	base
+	Prompt string `json:"prompt"`
+}
+
+func (e UserPromptSubmitEvent) Name() EventName { return EventUserPromptSubmit }
+
+// Decode reads a hook's stdin JSON payload from r and dispatches on its
+// hook_event_name field to return the matching typed Event. Callers that
+// only need the untyped HookInput fields (file_path, message, ...) can keep
+// using GetStdinData; Decode is for handlers that want the full,
+// per-event shape.
+func Decode(r io.Reader) (Event, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hook payload: %w", err)
+	}
+
+	var probe base
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse hook payload: %w", err)
+	}
+
+	switch probe.HookEventName {
+	case EventPreToolUse:
+		var e PreToolUseEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse PreToolUse payload: %w", err)
+		}
+		return e, nil
+	case EventPostToolUse:
+		var e PostToolUseEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse PostToolUse payload: %w", err)
+		}
+		return e, nil
+	case EventNotification:
+		var e NotificationEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse Notification payload: %w", err)
+		}
+		return e, nil
+	case EventStop:
+		var e StopEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse Stop payload: %w", err)
+		}
+		return e, nil
+	case EventSubagentStop:
+		var e SubagentStopEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse SubagentStop payload: %w", err)
+		}
+		return e, nil
+	case EventUserPromptSubmit:
+		var e UserPromptSubmitEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse UserPromptSubmit payload: %w", err)
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHookEvent, probe.HookEventName)
+	}
+}
@@ -1,16 +1,16 @@
 package hook
 
 import (
-	"bufio"
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 	"time"
 
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/inbox"
 	"github.com/ryantking/agentctl/internal/notify"
+	"github.com/ryantking/agentctl/internal/transcript"
 )
 
 // detectAgent detects the agent type and returns (appName, sender).
@@ -26,20 +26,20 @@ func detectAgent() (string, string) {
 		// CURSOR_CLI is set, so it's Cursor IDE
 		return "Cursor", notify.SenderCursor
 	}
-	
+
 	// Check for Cursor IDE (desktop app) - CURSOR_CLI or CURSOR_CLI_MODE indicates IDE
 	if os.Getenv("CURSOR_CLI") != "" || os.Getenv("CURSOR_CLI_MODE") != "" {
 		return "Cursor", notify.SenderCursor
 	}
-	
+
 	// Check for Claude Code environment variables
 	// Claude Code typically sets these when running
-	if os.Getenv("CLAUDE_CODE") != "" || 
-	   os.Getenv("ANTHROPIC_CLAUDE") != "" ||
-	   os.Getenv("CLAUDE_DESKTOP") != "" {
+	if os.Getenv("CLAUDE_CODE") != "" ||
+		os.Getenv("ANTHROPIC_CLAUDE") != "" ||
+		os.Getenv("CLAUDE_DESKTOP") != "" {
 		return "Claude Code", notify.SenderClaudeCode
 	}
-	
+
 	// Check for explicit sender override
 	if sender := os.Getenv("AGENTCTL_NOTIFICATION_SENDER"); sender != "" {
 		// Try to infer app name from sender
@@ -51,7 +51,7 @@ func detectAgent() (string, string) {
 		}
 		return "Agent", sender
 	}
-	
+
 	// No known agent detected - return empty sender (no custom icon)
 	return "Claude Code", ""
 }
@@ -78,21 +78,51 @@ func NotifyInputWithSender(message string, appName, sender string) error {
 	})
 }
 
-// NotifyStop sends notification when Claude completes a task.
+// NotifyStop sends notification when Claude completes a task, then
+// best-effort refreshes the current workspace's inbox so new review
+// comments on its PR show up in `agentctl inbox list` without the user
+// having to ask for them.
 func NotifyStop(transcriptPath string) error {
 	appName, sender := detectAgent()
-	return NotifyStopWithSender(transcriptPath, appName, sender)
+	err := NotifyStopWithSender(transcriptPath, appName, sender)
+	syncWorkspaceInbox()
+	return err
 }
 
-// NotifyStopWithSender sends stop notification with a custom sender.
+// syncWorkspaceInbox refreshes inbox notifications for the repository the
+// hook is running in. Failures (no repo, no remote provider configured, no
+// network) are swallowed: this is a convenience side effect of completing
+// a task, not something that should fail the stop hook.
+func syncWorkspaceInbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repoRoot, err := git.GetRepoRoot(ctx)
+	if err != nil {
+		return
+	}
+
+	_, _ = inbox.Sync(ctx, repoRoot)
+}
+
+// NotifyStopWithSender sends stop notification with a custom sender. When
+// transcriptPath is available, the message becomes a two-line summary: the
+// cleaned final assistant text, then a stats line (tools used, tokens,
+// duration) computed across the whole transcript rather than just its last
+// line.
 func NotifyStopWithSender(transcriptPath string, appName, sender string) error {
 	projectName := getProjectName()
 	timeStr := getTime()
 
 	message := fmt.Sprintf("Completed at %s", timeStr)
 	if transcriptPath != "" {
-		if finalResponse := extractFinalResponse(transcriptPath, 200); finalResponse != "" {
-			message = finalResponse
+		if summary, err := summarizeTranscript(transcriptPath); err == nil {
+			if headline, subtitle := transcript.Headline(summary); headline != "" {
+				message = headline
+				if subtitle != "" {
+					message = fmt.Sprintf("%s\n%s", headline, subtitle)
+				}
+			}
 		}
 	}
 
@@ -106,6 +136,49 @@ func NotifyStopWithSender(transcriptPath string, appName, sender string) error {
 	})
 }
 
+// summarizeTranscript resolves transcriptPath (hooks pass it relative to the
+// user's home directory) and summarizes it via the transcript package.
+func summarizeTranscript(transcriptPath string) (*transcript.Summary, error) {
+	path := filepath.Clean(transcriptPath)
+	if !filepath.IsAbs(path) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	return transcript.Summarize(file)
+}
+
+// NotifySync sends a notification when a workspace sync completes.
+func NotifySync(message string) error {
+	appName, sender := detectAgent()
+	return NotifySyncWithSender(message, appName, sender)
+}
+
+// NotifySyncWithSender sends sync notification with a custom sender.
+func NotifySyncWithSender(message string, appName, sender string) error {
+	projectName := getProjectName()
+	if message == "" {
+		message = "Workspace synced"
+	}
+	return notify.Send(notify.Options{
+		Title:    fmt.Sprintf("🔄 %s", appName),
+		Subtitle: projectName,
+		Message:  message,
+		Sound:    "Pop",
+		Group:    fmt.Sprintf("claude-code-%s", projectName),
+		Sender:   sender,
+	})
+}
+
 // NotifyError sends error notification.
 func NotifyError(message string) error {
 	appName, sender := detectAgent()
@@ -139,75 +212,3 @@ func getProjectName() string {
 func getTime() string {
 	return time.Now().Format("3:04 PM")
 }
-
-func extractFinalResponse(transcriptPath string, maxLength int) string {
-	path := filepath.Clean(transcriptPath)
-	if !filepath.IsAbs(path) {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ""
-		}
-		path = filepath.Join(home, path)
-	}
-
-	file, err := os.Open(path)
-	if err != nil {
-		return ""
-	}
-	defer file.Close()
-
-	var lastResponse string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		var entry map[string]interface{}
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
-			continue
-		}
-
-		if entry["type"] == "assistant" {
-			if message, ok := entry["message"].(map[string]interface{}); ok {
-				if content, ok := message["content"].([]interface{}); ok {
-					for _, block := range content {
-						if blockMap, ok := block.(map[string]interface{}); ok {
-							if blockMap["type"] == "text" {
-								if text, ok := blockMap["text"].(string); ok {
-									lastResponse = text
-								}
-							}
-						} else if text, ok := block.(string); ok {
-							lastResponse = text
-						}
-					}
-				}
-			}
-		}
-	}
-
-	if lastResponse == "" {
-		return ""
-	}
-
-	// Truncate and clean up for notification
-	text := strings.TrimSpace(lastResponse)
-	firstLine := strings.Split(text, "\n")[0]
-
-	// Strip markdown formatting
-	re := regexp.MustCompile(`\*\*(.+?)\*\*`)
-	firstLine = re.ReplaceAllString(firstLine, "$1")
-	re = regexp.MustCompile(`\*(.+?)\*`)
-	firstLine = re.ReplaceAllString(firstLine, "$1")
-	re = regexp.MustCompile("`(.+?)`")
-	firstLine = re.ReplaceAllString(firstLine, "$1")
-	re = regexp.MustCompile(`^#+\s*`)
-	firstLine = re.ReplaceAllString(firstLine, "")
-
-	if len(firstLine) > maxLength {
-		return firstLine[:maxLength-3] + "..."
-	}
-	return firstLine
-}
@@ -0,0 +1,56 @@
+package hook
+
+import (
+	"context"
+
+	"github.com/ryantking/agentctl/internal/slack"
+)
+
+// NotifySlack posts a Claude Code notification to the configured Slack
+// webhook as a Block Kit rich_text message instead of a plain-text string:
+// toolName renders bold, filePath as a link, and output (a tool's stdout or
+// error text) as a preformatted code block. sessionID is used to look up an
+// @-mention in the configured notifications.slack.mentions.
+//
+// It's a no-op (returning nil) when notifications.slack.webhookUrl isn't
+// configured, the same "silently skip when unconfigured" convention
+// report.LoadConfig uses, so it's safe to wire into every hook unconditionally.
+func NotifySlack(ctx context.Context, sessionID, toolName, filePath, message, output string) error {
+	cfg := slack.LoadConfig(ctx)
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+
+	var elements []slack.Element
+	if mention, ok := cfg.MentionFor(sessionID); ok {
+		elements = append(elements, mention, slack.Text(" "))
+	}
+
+	elements = append(elements, slack.Emoji("x"), slack.Text(" "))
+	if toolName != "" {
+		elements = append(elements, slack.Bold(toolName), slack.Text(" failed"))
+	} else {
+		elements = append(elements, slack.Text("Task failed"))
+	}
+
+	if filePath != "" {
+		elements = append(elements, slack.Text(" on "), slack.Link(fileLink(filePath), filePath))
+	}
+	if message != "" {
+		elements = append(elements, slack.Text(": "+message))
+	}
+
+	msg := slack.NewMessage(cfg.Channel)
+	msg.AddSection(elements...)
+	if output != "" {
+		msg.AddPreformatted(output)
+	}
+
+	return slack.Send(ctx, cfg.WebhookURL, msg)
+}
+
+// fileLink turns a hook-reported file path into a clickable file:// URL so
+// it renders as a link rather than plain text in the Slack message.
+func fileLink(path string) string {
+	return "file://" + path
+}
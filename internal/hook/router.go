@@ -0,0 +1,201 @@
+package hook
+
+import "encoding/json"
+
+// Permission is a PreToolUse handler's verdict on whether a tool call
+// should proceed.
+type Permission string
+
+const (
+	// PermissionAllow lets the tool call proceed without prompting.
+	PermissionAllow Permission = "allow"
+	// PermissionDeny blocks the tool call outright.
+	PermissionDeny Permission = "deny"
+	// PermissionAsk falls back to Claude Code's normal permission prompt.
+	PermissionAsk Permission = "ask"
+)
+
+// HookDecision is what a handler returns for an event. Handlers that have
+// no opinion return the zero value, which Dispatch treats as "ask" (i.e.
+// defer to Claude Code's default behavior).
+type HookDecision struct {
+	Permission     Permission
+	Reason         string
+	SuggestedEdits map[string]interface{}
+}
+
+// protocolOutput is the shape Dispatch's caller marshals back to Claude
+// Code on stdout, per the hook JSON output protocol.
+type protocolOutput struct {
+	HookSpecificOutput protocolHookSpecificOutput `json:"hookSpecificOutput"`
+}
+
+type protocolHookSpecificOutput struct {
+	HookEventName            EventName              `json:"hookEventName"`
+	PermissionDecision       Permission             `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string                 `json:"permissionDecisionReason,omitempty"`
+	SuggestedEdits           map[string]interface{} `json:"suggestedEdits,omitempty"`
+}
+
+// Encode renders d as the JSON payload Claude Code expects on stdout for
+// eventName. Handlers that returned the zero HookDecision produce no
+// permissionDecision field, which Claude Code treats the same as "ask".
+func (d HookDecision) Encode(eventName EventName) ([]byte, error) {
+	return json.Marshal(protocolOutput{
+		HookSpecificOutput: protocolHookSpecificOutput{
+			HookEventName:            eventName,
+			PermissionDecision:       d.Permission,
+			PermissionDecisionReason: d.Reason,
+			SuggestedEdits:           d.SuggestedEdits,
+		},
+	})
+}
+
+// PreToolUseHandler inspects a tool call before it runs.
+type PreToolUseHandler func(PreToolUseEvent) (HookDecision, error)
+
+// PostToolUseHandler inspects a tool call's result after it runs.
+type PostToolUseHandler func(PostToolUseEvent) (HookDecision, error)
+
+// NotificationHandler reacts to a Notification event.
+type NotificationHandler func(NotificationEvent) error
+
+// StopHandler reacts to a Stop event.
+type StopHandler func(StopEvent) error
+
+// SubagentStopHandler reacts to a SubagentStop event.
+type SubagentStopHandler func(SubagentStopEvent) error
+
+// UserPromptSubmitHandler reacts to a UserPromptSubmit event.
+type UserPromptSubmitHandler func(UserPromptSubmitEvent) error
+
+// anyTool is the key OnPreToolUse/OnPostToolUse register under when called
+// with an empty tool name, matching every tool.
+const anyTool = ""
+
+// Router dispatches a decoded Event to the handlers registered for its
+// event type (and, for tool-use events, its tool name). Handlers for a
+// given event/tool run in registration order; for PreToolUse/PostToolUse,
+// the first non-zero HookDecision short-circuits the rest.
+type Router struct {
+	preToolUse       map[string][]PreToolUseHandler
+	postToolUse      map[string][]PostToolUseHandler
+	notification     []NotificationHandler
+	stop             []StopHandler
+	subagentStop     []SubagentStopHandler
+	userPromptSubmit []UserPromptSubmitHandler
+}
+
+// NewRouter creates an empty Router.
+func NewRouter() *Router {
+	return &Router{
+		preToolUse:  make(map[string][]PreToolUseHandler),
+		postToolUse: make(map[string][]PostToolUseHandler),
+	}
+}
+
+// OnPreToolUse registers fn for PreToolUse events. An empty tool matches
+// every tool; fn runs after any handler already registered for the
+// specific tool name.
+func (r *Router) OnPreToolUse(tool string, fn PreToolUseHandler) {
+	r.preToolUse[tool] = append(r.preToolUse[tool], fn)
+}
+
+// OnPostToolUse registers fn for PostToolUse events, same matching rules
+// as OnPreToolUse.
+func (r *Router) OnPostToolUse(tool string, fn PostToolUseHandler) {
+	r.postToolUse[tool] = append(r.postToolUse[tool], fn)
+}
+
+// OnNotification registers fn for Notification events.
+func (r *Router) OnNotification(fn NotificationHandler) {
+	r.notification = append(r.notification, fn)
+}
+
+// OnStop registers fn for Stop events.
+func (r *Router) OnStop(fn StopHandler) {
+	r.stop = append(r.stop, fn)
+}
+
+// OnSubagentStop registers fn for SubagentStop events.
+func (r *Router) OnSubagentStop(fn SubagentStopHandler) {
+	r.subagentStop = append(r.subagentStop, fn)
+}
+
+// OnUserPromptSubmit registers fn for UserPromptSubmit events.
+func (r *Router) OnUserPromptSubmit(fn UserPromptSubmitHandler) {
+	r.userPromptSubmit = append(r.userPromptSubmit, fn)
+}
+
+// Dispatch runs the handlers registered for event's type, returning the
+// first non-zero HookDecision for PreToolUse/PostToolUse events. Events
+// with no typed HookDecision (Notification, Stop, SubagentStop,
+// UserPromptSubmit) always return the zero HookDecision; their handlers
+// run for side effects only.
+func (r *Router) Dispatch(event Event) (HookDecision, error) {
+	switch e := event.(type) {
+	case PreToolUseEvent:
+		for _, fn := range r.handlersFor(r.preToolUse, e.ToolName) {
+			decision, err := fn(e)
+			if err != nil {
+				return HookDecision{}, err
+			}
+			if decision.Permission != "" {
+				return decision, nil
+			}
+		}
+	case PostToolUseEvent:
+		for _, fn := range r.handlersForPost(e.ToolName) {
+			decision, err := fn(e)
+			if err != nil {
+				return HookDecision{}, err
+			}
+			if decision.Permission != "" {
+				return decision, nil
+			}
+		}
+	case NotificationEvent:
+		for _, fn := range r.notification {
+			if err := fn(e); err != nil {
+				return HookDecision{}, err
+			}
+		}
+	case StopEvent:
+		for _, fn := range r.stop {
+			if err := fn(e); err != nil {
+				return HookDecision{}, err
+			}
+		}
+	case SubagentStopEvent:
+		for _, fn := range r.subagentStop {
+			if err := fn(e); err != nil {
+				return HookDecision{}, err
+			}
+		}
+	case UserPromptSubmitEvent:
+		for _, fn := range r.userPromptSubmit {
+			if err := fn(e); err != nil {
+				return HookDecision{}, err
+			}
+		}
+	}
+	return HookDecision{}, nil
+}
+
+func (r *Router) handlersFor(handlers map[string][]PreToolUseHandler, tool string) []PreToolUseHandler {
+	out := make([]PreToolUseHandler, 0, len(handlers[tool])+len(handlers[anyTool]))
+	out = append(out, handlers[tool]...)
+	if tool != anyTool {
+		out = append(out, handlers[anyTool]...)
+	}
+	return out
+}
+
+func (r *Router) handlersForPost(tool string) []PostToolUseHandler {
+	out := make([]PostToolUseHandler, 0, len(r.postToolUse[tool])+len(r.postToolUse[anyTool]))
+	out = append(out, r.postToolUse[tool]...)
+	if tool != anyTool {
+		out = append(out, r.postToolUse[anyTool]...)
+	}
+	return out
+}
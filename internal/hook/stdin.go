@@ -1,6 +1,7 @@
 package hook
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
@@ -8,27 +9,63 @@ import (
 	"strings"
 )
 
+// lastStdinPayload caches the raw bytes most recently read by
+// GetStdinData, so a failure captured later in the same process (e.g.
+// report.Wrap) can attach the payload a hook failed on without reading
+// stdin a second time.
+var lastStdinPayload []byte
+
+// LastStdinPayload returns the raw bytes most recently read by
+// GetStdinData in this process, or nil if it hasn't been called yet (or
+// found no stdin data).
+func LastStdinPayload() []byte {
+	return lastStdinPayload
+}
+
 // HookInput represents the JSON input from Claude Code hooks.
 type HookInput struct {
-	SessionID     string                 `json:"session_id"`
-	ToolInput     map[string]interface{} `json:"tool_input"`
-	TranscriptPath string                `json:"transcript_path"`
-	Message       string                 `json:"message"`
-	NotificationType string             `json:"notification_type"`
+	SessionID        string                 `json:"session_id"`
+	ToolName         string                 `json:"tool_name"`
+	ToolInput        map[string]interface{} `json:"tool_input"`
+	ToolResponse     map[string]interface{} `json:"tool_response"`
+	TranscriptPath   string                 `json:"transcript_path"`
+	Message          string                 `json:"message"`
+	NotificationType string                 `json:"notification_type"`
 }
 
-// GetStdinData reads stdin JSON data from hooks.
-func GetStdinData() (*HookInput, error) {
+// GetStdinData reads stdin JSON data from hooks. It respects ctx
+// cancellation (e.g. the root --timeout flag) even though os.Stdin itself
+// has no cancellable Read: the read runs in a goroutine and GetStdinData
+// returns as soon as ctx is done, leaving that goroutine to finish on its
+// own once stdin is closed or produces data.
+func GetStdinData(ctx context.Context) (*HookInput, error) {
 	// Check if stdin is a TTY (interactive)
 	if isTTY(os.Stdin) {
 		return nil, nil
 	}
 
-	data, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		return nil, err
+	type result struct {
+		data []byte
+		err  error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		data, err := io.ReadAll(os.Stdin)
+		resultCh <- result{data, err}
+	}()
+
+	var data []byte
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-resultCh:
+		if r.err != nil {
+			return nil, r.err
+		}
+		data = r.data
 	}
 
+	lastStdinPayload = data
 	if len(data) == 0 {
 		return nil, nil
 	}
@@ -60,6 +97,28 @@ func GetTranscriptPath(input *HookInput) string {
 	return input.TranscriptPath
 }
 
+// GetToolOutput extracts a human-readable rendering of tool_response from
+// hook input, for commands that want to show a tool's result (e.g.
+// notify-slack's preformatted code block). It prefers a single
+// output-like string field when the tool response has one (Bash's
+// "output"/"stdout", a surfaced "error"), and falls back to indenting the
+// whole tool_response object otherwise.
+func GetToolOutput(input *HookInput) string {
+	if input == nil || input.ToolResponse == nil {
+		return ""
+	}
+	for _, key := range []string{"output", "stdout", "content", "error"} {
+		if s, ok := input.ToolResponse[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	data, err := json.MarshalIndent(input.ToolResponse, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // IsSubagent checks if this is a subagent based on transcript path.
 func IsSubagent(transcriptPath string) bool {
 	if transcriptPath == "" {
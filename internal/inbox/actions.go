@@ -0,0 +1,84 @@
+package inbox
+
+import "fmt"
+
+// Filter narrows a list of items for `inbox list`.
+type Filter struct {
+	Unread bool
+	Pinned bool
+	Types  []string
+}
+
+// List returns the items in items matching f.
+func List(items []Item, f Filter) []Item {
+	var out []Item
+	for _, item := range items {
+		if f.Unread && item.Read {
+			continue
+		}
+		if f.Pinned && !item.Pinned {
+			continue
+		}
+		if len(f.Types) > 0 && !containsType(f.Types, item.Type) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func containsType(types []string, t string) bool {
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns db's item with the given numeric ID.
+func Find(db *Database, id int) (*Item, error) {
+	for i := range db.Items {
+		if db.Items[i].ID == id {
+			return &db.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no inbox item with ID %d", id)
+}
+
+// MarkRead marks the item with the given ID as read.
+func MarkRead(db *Database, id int) error {
+	item, err := Find(db, id)
+	if err != nil {
+		return err
+	}
+	item.Read = true
+	return nil
+}
+
+// MarkUnread marks the item with the given ID as unread.
+func MarkUnread(db *Database, id int) error {
+	item, err := Find(db, id)
+	if err != nil {
+		return err
+	}
+	item.Read = false
+	return nil
+}
+
+// Pin toggles the pinned state of the item with the given ID.
+func Pin(db *Database, id int) error {
+	item, err := Find(db, id)
+	if err != nil {
+		return err
+	}
+	item.Pinned = !item.Pinned
+	return nil
+}
+
+// MarkAllRead marks every item in db as read.
+func MarkAllRead(db *Database) {
+	for i := range db.Items {
+		db.Items[i].Read = true
+	}
+}
@@ -0,0 +1,79 @@
+// Package inbox caches remote notification threads locally so they can be
+// addressed by stable numeric shortcuts instead of opaque provider thread
+// IDs, and tracks read/pinned state across syncs.
+package inbox
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Item is a locally cached view of a remote notification thread.
+type Item struct {
+	ID        int    `json:"id"`
+	ThreadID  string `json:"thread_id"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Reason    string `json:"reason"`
+	Type      string `json:"type"`
+	Read      bool   `json:"read"`
+	Pinned    bool   `json:"pinned"`
+	FetchedAt string `json:"fetched_at"`
+}
+
+// Database is the `~/.config/agentctl/inbox.db` schema.
+type Database struct {
+	NextID int    `json:"next_id"`
+	Items  []Item `json:"items"`
+}
+
+// Path returns the path to the inbox database.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentctl", "inbox.db"), nil
+}
+
+// Load reads the inbox database, returning an empty Database if none exists
+// yet (e.g. `agentctl inbox` has never synced before).
+func Load() (*Database, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the user's home directory
+	if os.IsNotExist(err) {
+		return &Database{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var db Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// Save writes db to the inbox database.
+func Save(db *Database) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(db, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644) //nolint:gosec // Database needs to be readable
+}
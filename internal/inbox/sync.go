@@ -0,0 +1,78 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/remote"
+)
+
+// Sync fetches repoRoot's current remote notifications and merges them into
+// the local database, preserving Read/Pinned state for threads already
+// seen and assigning new sequential IDs to unseen ones. It returns the full
+// merged item list.
+func Sync(ctx context.Context, repoRoot string) ([]Item, error) {
+	provider, err := remote.DetectProvider(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect remote provider: %w", err)
+	}
+
+	notifications, err := provider.ListNotifications(ctx, repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	db, err := Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load inbox database: %w", err)
+	}
+
+	byThread := make(map[string]int, len(db.Items))
+	for i, item := range db.Items {
+		byThread[item.ThreadID] = i
+	}
+
+	fetchedAt := time.Now().Format(time.RFC3339)
+	for _, n := range notifications {
+		if i, ok := byThread[n.ID]; ok {
+			db.Items[i].Title = n.Title
+			db.Items[i].URL = n.URL
+			db.Items[i].Reason = n.Reason
+			db.Items[i].Type = classify(n)
+			db.Items[i].FetchedAt = fetchedAt
+			continue
+		}
+
+		db.NextID++
+		db.Items = append(db.Items, Item{
+			ID:        db.NextID,
+			ThreadID:  n.ID,
+			Title:     n.Title,
+			URL:       n.URL,
+			Reason:    n.Reason,
+			Type:      classify(n),
+			FetchedAt: fetchedAt,
+		})
+	}
+
+	if err := Save(db); err != nil {
+		return nil, fmt.Errorf("failed to save inbox database: %w", err)
+	}
+	return db.Items, nil
+}
+
+// classify buckets a notification into "pr", "issue", or "ci" for the
+// `--type` filter, based on the provider's reason string.
+func classify(n remote.Notification) string {
+	reason := strings.ToLower(n.Reason)
+	switch {
+	case strings.Contains(reason, "ci") || strings.Contains(reason, "check"):
+		return "ci"
+	case strings.Contains(reason, "issue"):
+		return "issue"
+	default:
+		return "pr"
+	}
+}
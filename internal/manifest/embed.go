@@ -0,0 +1,6 @@
+package manifest
+
+import _ "embed"
+
+//go:embed default.yaml
+var defaultManifestYAML []byte
@@ -0,0 +1,126 @@
+// Package manifest loads the declarative agentctl.yaml manifest that
+// `agentctl init` installs from. Its MCP servers and settings overrides are
+// applied as listed; its agents and skills lists only switch the whole
+// embedded bundle on or off (entry names, sources and versions are not
+// consulted); and setup does not read its hooks list. A default manifest is
+// embedded so behavior is unchanged when a user doesn't supply their own.
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entry describes a single agent or skill to install.
+type Entry struct {
+	Name    string `yaml:"name"`
+	Source  string `yaml:"source"` // e.g. "embedded"; Install always copies the bundled templates tree and does not read this field
+	Version string `yaml:"version,omitempty"`
+	Enabled *bool  `yaml:"enabled,omitempty"` // defaults to true when omitted
+}
+
+// IsEnabled reports whether the entry should be installed.
+func (e Entry) IsEnabled() bool {
+	return e.Enabled == nil || *e.Enabled
+}
+
+// MCPServer describes one entry of the `mcp_servers` map.
+type MCPServer struct {
+	Type    string            `yaml:"type"` // "http", "sse", or "stdio"
+	URL     string            `yaml:"url,omitempty"`
+	Command string            `yaml:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+// ToJSON converts the server definition to the map shape `.mcp.json`
+// expects.
+func (s MCPServer) ToJSON() map[string]interface{} {
+	m := map[string]interface{}{"type": s.Type}
+	if s.URL != "" {
+		m["url"] = s.URL
+	}
+	if s.Command != "" {
+		m["command"] = s.Command
+	}
+	if len(s.Args) > 0 {
+		m["args"] = s.Args
+	}
+	if len(s.Env) > 0 {
+		m["env"] = s.Env
+	}
+	return m
+}
+
+// Manifest is the agentctl.yaml schema.
+type Manifest struct {
+	Agents            []Entry                `yaml:"agents"`
+	Skills            []Entry                `yaml:"skills"`
+	MCPServers        map[string]MCPServer   `yaml:"mcp_servers"`
+	SettingsOverrides map[string]interface{} `yaml:"settings_overrides"`
+	Hooks             []string               `yaml:"hooks"`
+}
+
+// Load reads and parses a manifest from path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is supplied explicitly by the caller (CLI flag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// LoadDefault returns the manifest shipped embedded in the binary,
+// reflecting agentctl's built-in defaults (the context7/linear MCP servers,
+// the full embedded agent/skill bundle, and the hooks listed in
+// default.yaml).
+func LoadDefault() (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(defaultManifestYAML, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded default manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// EnabledAgents returns the agents entries with Enabled not explicitly
+// false.
+func (m *Manifest) EnabledAgents() []Entry {
+	return enabledEntries(m.Agents)
+}
+
+// EnabledSkills returns the skills entries with Enabled not explicitly
+// false.
+func (m *Manifest) EnabledSkills() []Entry {
+	return enabledEntries(m.Skills)
+}
+
+func enabledEntries(entries []Entry) []Entry {
+	var result []Entry
+	for _, e := range entries {
+		if e.IsEnabled() {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// HookEnabled reports whether name appears in the manifest's hooks list. An
+// empty hooks list means all hooks are registered (the default).
+func (m *Manifest) HookEnabled(name string) bool {
+	if len(m.Hooks) == 0 {
+		return true
+	}
+	for _, h := range m.Hooks {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// notifyWebhookEnv, when set, is a URL jsonFallbackBackend POSTs
+// notifications to instead of printing them — useful for routing CI/tmux
+// notifications to Slack or another webhook-based receiver.
+const notifyWebhookEnv = "AGENTCTL_NOTIFY_WEBHOOK"
+
+// jsonFallbackBackend is always Available; it's the last resort in
+// backends, so Send never fails outright on a headless CI box or a tmux
+// session with no notification daemon running.
+type jsonFallbackBackend struct{}
+
+func (b *jsonFallbackBackend) Available() bool {
+	return true
+}
+
+func (b *jsonFallbackBackend) Send(opts Options) error {
+	data, err := json.Marshal(map[string]string{
+		"title":    opts.Title,
+		"subtitle": opts.Subtitle,
+		"message":  opts.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	if url := os.Getenv(notifyWebhookEnv); url != "" {
+		return postWebhook(url, data)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+func postWebhook(url string, data []byte) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to post notification webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
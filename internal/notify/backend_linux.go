@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// linuxBackend sends desktop notifications on Linux via notify-send
+// (libnotify), the standard mechanism outside of WSL, which routes through
+// windowsBackend instead.
+type linuxBackend struct{}
+
+func (b *linuxBackend) Available() bool {
+	if runtime.GOOS != "linux" || isWSL() {
+		return false
+	}
+	_, err := exec.LookPath("notify-send")
+	return err == nil
+}
+
+func (b *linuxBackend) Send(opts Options) error {
+	args := []string{"--urgency=" + urgencyFor(opts.Sound)}
+	if icon := iconFor(opts.Sender); icon != "" {
+		args = append(args, "--icon", icon)
+	}
+	if opts.Group != "" {
+		args = append(args, "--hint=string:desktop-entry:"+opts.Group)
+	}
+
+	title := opts.Title
+	if opts.Subtitle != "" {
+		title = fmt.Sprintf("%s — %s", opts.Title, opts.Subtitle)
+	}
+	args = append(args, title, opts.Message)
+
+	return exec.Command("notify-send", args...).Run()
+}
+
+// urgencyFor maps the macOS-style Sound hint ("Basso" is the one NotifyError
+// uses) to a notify-send urgency level.
+func urgencyFor(sound string) string {
+	if sound == "Basso" {
+		return "critical"
+	}
+	return "normal"
+}
+
+// iconFor maps a macOS sender bundle ID to a representative icon name for
+// notify-send's --icon, the closest Linux equivalent of the sender-based
+// app icon terminal-notifier shows.
+func iconFor(sender string) string {
+	switch sender {
+	case SenderClaudeCode:
+		return "com.anthropic.claude"
+	case SenderCursor:
+		return "cursor"
+	default:
+		return ""
+	}
+}
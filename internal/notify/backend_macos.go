@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// macOSBackend sends notifications via terminal-notifier when available
+// (the only mechanism that supports a custom sender/icon), falling back to
+// osascript otherwise.
+type macOSBackend struct{}
+
+func (b *macOSBackend) Available() bool {
+	return runtime.GOOS == "darwin"
+}
+
+func (b *macOSBackend) Send(opts Options) error {
+	if hasTerminalNotifier() {
+		return sendWithTerminalNotifier(opts)
+	}
+	return sendWithOSAScript(opts)
+}
+
+// hasTerminalNotifier checks if terminal-notifier is available.
+func hasTerminalNotifier() bool {
+	_, err := exec.LookPath("terminal-notifier")
+	return err == nil
+}
+
+// sendWithTerminalNotifier sends notification using terminal-notifier (supports custom sender).
+func sendWithTerminalNotifier(opts Options) error {
+	args := []string{
+		"-title", opts.Title,
+		"-subtitle", opts.Subtitle,
+		"-message", opts.Message,
+	}
+
+	// Add sender if provided (for custom icons)
+	if opts.Sender != "" {
+		args = append(args, "-sender", opts.Sender)
+	}
+
+	if opts.Sound != "" {
+		args = append(args, "-sound", opts.Sound)
+	}
+	if opts.Group != "" {
+		args = append(args, "-group", opts.Group)
+	}
+
+	cmd := exec.Command("terminal-notifier", args...)
+	return cmd.Run()
+}
+
+// sendWithOSAScript sends notification using osascript (fallback, no custom sender support).
+func sendWithOSAScript(opts Options) error {
+	soundClause := ""
+	if opts.Sound != "" {
+		soundClause = fmt.Sprintf(` sound name "%s"`, opts.Sound)
+	}
+	script := fmt.Sprintf(`display notification "%s" with title "%s" subtitle "%s"%s`,
+		opts.Message, opts.Title, opts.Subtitle, soundClause)
+	cmd := exec.Command("osascript", "-e", script)
+	return cmd.Run()
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// windowsBackend sends desktop notifications via PowerShell's BurntToast
+// module (New-BurntToastNotification). It covers both native Windows and
+// WSL, which can run powershell.exe through the Windows interop shim (the
+// same trick hub uses to open a browser from WSL).
+type windowsBackend struct{}
+
+func (b *windowsBackend) Available() bool {
+	if runtime.GOOS != "windows" && !isWSL() {
+		return false
+	}
+	_, err := exec.LookPath("powershell.exe")
+	return err == nil
+}
+
+func (b *windowsBackend) Send(opts Options) error {
+	text := opts.Message
+	if opts.Subtitle != "" {
+		text = opts.Subtitle + "\n" + opts.Message
+	}
+
+	// BurntToast ties a custom app icon/name to a registered AUMID, which
+	// agentctl doesn't register, so opts.Sender has no effect here (unlike
+	// the macOS/Linux backends, which map it to a real icon).
+	script := fmt.Sprintf("New-BurntToastNotification -Text %s, %s", psQuote(opts.Title), psQuote(text))
+
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-Command", script)
+	return cmd.Run()
+}
+
+// psQuote wraps s in single quotes for a PowerShell command line, escaping
+// any embedded single quotes by doubling them.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
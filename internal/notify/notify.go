@@ -1,15 +1,15 @@
+// Package notify sends desktop notifications for the hook commands
+// (notify-input, notify-stop, notify-error), dispatching to whichever
+// platform backend is available.
 package notify
 
-import (
-	"fmt"
-	"os/exec"
-)
+import "fmt"
 
 // Common sender bundle IDs for macOS notifications.
 const (
 	// SenderClaudeCode is the bundle ID for Claude Desktop.
 	SenderClaudeCode = "com.anthropic.claudefordesktop"
-	
+
 	// SenderCursor is the bundle ID for Cursor.
 	SenderCursor = "com.todesktop.230313mzl4w4u92"
 )
@@ -21,58 +21,39 @@ type Options struct {
 	Message  string
 	Sound    string
 	Group    string
-	Sender   string // macOS bundle ID for custom icon (e.g., "com.anthropic.claudefordesktop", "com.todesktop.230313mzl4w4u92")
-}
-
-// Send sends a macOS notification with the given options.
-// Uses terminal-notifier if available (supports custom sender/icons), otherwise falls back to osascript.
-func Send(opts Options) error {
-	if hasTerminalNotifier() {
-		return sendWithTerminalNotifier(opts)
-	}
-	return sendWithOSAScript(opts)
+	Sender   string // macOS bundle ID; mapped to an icon/AppLogo on other backends where supported
 }
 
-// hasTerminalNotifier checks if terminal-notifier is available.
-func hasTerminalNotifier() bool {
-	_, err := exec.LookPath("terminal-notifier")
-	return err == nil
+// Backend delivers a single notification using whatever mechanism is
+// available in the current environment.
+type Backend interface {
+	// Available reports whether this backend can be used right now (the
+	// expected OS, a required binary on PATH, etc).
+	Available() bool
+	// Send delivers the notification.
+	Send(opts Options) error
 }
 
-// sendWithTerminalNotifier sends notification using terminal-notifier (supports custom sender).
-func sendWithTerminalNotifier(opts Options) error {
-	args := []string{
-		"-title", opts.Title,
-		"-subtitle", opts.Subtitle,
-		"-message", opts.Message,
-	}
-	
-	// Add sender if provided (for custom icons)
-	if opts.Sender != "" {
-		args = append(args, "-sender", opts.Sender)
-	}
-	
-	if opts.Sound != "" {
-		args = append(args, "-sound", opts.Sound)
-	}
-	if opts.Group != "" {
-		args = append(args, "-group", opts.Group)
-	}
-
-	cmd := exec.Command("terminal-notifier", args...)
-	return cmd.Run()
+// backends is the list of backends Send tries, in priority order. The
+// first one whose Available() returns true handles the notification;
+// jsonFallbackBackend is always available, so Send never fails solely for
+// lack of a usable backend.
+var backends = []Backend{
+	&macOSBackend{},
+	&linuxBackend{},
+	&windowsBackend{},
+	&jsonFallbackBackend{},
 }
 
-// sendWithOSAScript sends notification using osascript (fallback, no custom sender support).
-func sendWithOSAScript(opts Options) error {
-	soundClause := ""
-	if opts.Sound != "" {
-		soundClause = fmt.Sprintf(` sound name "%s"`, opts.Sound)
+// Send delivers a notification through the first available backend for the
+// current environment.
+func Send(opts Options) error {
+	for _, b := range backends {
+		if b.Available() {
+			return b.Send(opts)
+		}
 	}
-	script := fmt.Sprintf(`display notification "%s" with title "%s" subtitle "%s"%s`,
-		opts.Message, opts.Title, opts.Subtitle, soundClause)
-	cmd := exec.Command("osascript", "-e", script)
-	return cmd.Run()
+	return fmt.Errorf("no notification backend available")
 }
 
 // HasTerminalNotifier returns whether terminal-notifier is available.
@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"os"
+	"strings"
+)
+
+// isWSL reports whether the process is running under Windows Subsystem for
+// Linux, detected by the "Microsoft" marker WSL's kernel build string adds
+// to /proc/version — the same trick hub uses to route browser-opens
+// through Windows.
+func isWSL() bool {
+	data, err := os.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), "Microsoft")
+}
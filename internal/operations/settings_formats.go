@@ -0,0 +1,41 @@
+package operations
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadYAMLSettings loads YAML settings from bytes, for `CLAUDE.md`
+// frontmatter and other YAML-formatted settings documents.
+func LoadYAMLSettings(data []byte) (map[string]interface{}, error) {
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveYAMLSettings saves YAML settings to bytes.
+func SaveYAMLSettings(settings map[string]interface{}) ([]byte, error) {
+	return yaml.Marshal(settings)
+}
+
+// LoadTOMLSettings loads TOML settings from bytes.
+func LoadTOMLSettings(data []byte) (map[string]interface{}, error) {
+	var settings map[string]interface{}
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveTOMLSettings saves TOML settings to bytes.
+func SaveTOMLSettings(settings map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(settings); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
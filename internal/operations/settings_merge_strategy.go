@@ -0,0 +1,446 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MergeStrategy selects the algorithm MergeSettings uses to combine base
+// and overlay.
+type MergeStrategy string
+
+const (
+	// StrategySmartUnion is MergeSettingsSmart's recursive-merge-plus-array-
+	// union behavior. The default when Strategy is unset.
+	StrategySmartUnion MergeStrategy = "smart_union"
+	// StrategyRFC7396 applies overlay as an RFC 7396 JSON Merge Patch: a
+	// null value in overlay deletes the corresponding key, and a
+	// non-object value replaces the base value wholesale.
+	StrategyRFC7396 MergeStrategy = "rfc7396"
+	// StrategyRFC6902 applies overlay as an RFC 6902 JSON Patch: an
+	// ordered []interface{} of {op,path,from,value} operations addressed
+	// by JSON Pointer.
+	StrategyRFC6902 MergeStrategy = "rfc6902"
+	// StrategyReplace discards base entirely in favor of overlay.
+	StrategyReplace MergeStrategy = "replace"
+)
+
+// MergeOptions configures MergeSettings.
+type MergeOptions struct {
+	// Strategy selects the merge algorithm. Defaults to StrategySmartUnion.
+	Strategy MergeStrategy
+}
+
+// MergeSettings combines base and overlay per opts.Strategy.
+//
+// overlay's shape depends on the strategy: StrategySmartUnion,
+// StrategyRFC7396, and StrategyReplace all expect a map[string]interface{};
+// StrategyRFC6902 expects a []interface{} of patch operations.
+//
+// StrategySmartUnion also honors two per-path override directives in
+// overlay, for forcing a whole-value replace on a key that would otherwise
+// be deep-merged (e.g. a permission allowlist): a sibling `__strategy`
+// key inside a nested object (`"hooks": {"__strategy": "replace", ...}`),
+// or a top-level `_strategies` map keyed by JSON Pointer
+// (`"_strategies": {"/hooks": "replace"}`). Both directive keys are
+// stripped from the result.
+func MergeSettings(base map[string]interface{}, overlay interface{}, opts MergeOptions) (map[string]interface{}, error) {
+	switch opts.Strategy {
+	case "", StrategySmartUnion:
+		overlayMap, ok := overlay.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s strategy requires a map overlay", StrategySmartUnion)
+		}
+		return mergeSmartWithOverrides(base, overlayMap), nil
+
+	case StrategyRFC7396:
+		overlayMap, ok := overlay.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s strategy requires a map overlay", StrategyRFC7396)
+		}
+		merged := mergePatch(base, overlayMap)
+		result, ok := merged.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s merge patch result is not an object", StrategyRFC7396)
+		}
+		return result, nil
+
+	case StrategyRFC6902:
+		ops, ok := overlay.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s strategy requires a patch document (array of operations)", StrategyRFC6902)
+		}
+		return applyJSONPatch(base, ops)
+
+	case StrategyReplace:
+		overlayMap, ok := overlay.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s strategy requires a map overlay", StrategyReplace)
+		}
+		return overlayMap, nil
+
+	default:
+		return nil, fmt.Errorf("unknown merge strategy: %s", opts.Strategy)
+	}
+}
+
+// mergeSmartWithOverrides is MergeSettingsSmart extended to honor the
+// __strategy/_strategies replace directives described on MergeSettings.
+func mergeSmartWithOverrides(base, overlay map[string]interface{}) map[string]interface{} {
+	strategies := map[string]string{}
+	if raw, ok := overlay["_strategies"].(map[string]interface{}); ok {
+		for pointer, v := range raw {
+			if s, ok := v.(string); ok {
+				strategies[pointer] = s
+			}
+		}
+	}
+
+	cleanOverlay := make(map[string]interface{}, len(overlay))
+	for k, v := range overlay {
+		if k != "_strategies" {
+			cleanOverlay[k] = v
+		}
+	}
+
+	return mergeSmartAt(base, cleanOverlay, strategies, "")
+}
+
+func mergeSmartAt(base, overlay map[string]interface{}, strategies map[string]string, path string) map[string]interface{} {
+	result := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		result[k] = v
+	}
+
+	for key, value := range overlay {
+		childPath := path + "/" + pointerEscape(key)
+
+		if childMap, ok := value.(map[string]interface{}); ok {
+			if directive, ok := childMap["__strategy"].(string); ok {
+				result[key] = stripDirective(childMap)
+				_ = directive // only "replace" is meaningful today; any other value still forces replace
+				continue
+			}
+		}
+
+		if strategies[childPath] == string(StrategyReplace) {
+			result[key] = value
+			continue
+		}
+
+		existing, ok := result[key]
+		switch {
+		case !ok:
+			result[key] = value
+		case isMap(value) && isMap(existing):
+			result[key] = mergeSmartAt(existing.(map[string]interface{}), value.(map[string]interface{}), strategies, childPath)
+		case isSlice(value) && isSlice(existing):
+			result[key] = mergeLists(existing.([]interface{}), value.([]interface{}))
+		default:
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+func stripDirective(m map[string]interface{}) map[string]interface{} {
+	clean := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != "__strategy" {
+			clean[k] = v
+		}
+	}
+	return clean
+}
+
+// pointerEscape escapes a single JSON Pointer reference token per RFC 6901.
+func pointerEscape(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return token
+}
+
+// mergePatch applies patch to target per RFC 7396 (JSON Merge Patch).
+func mergePatch(target, patch map[string]interface{}) interface{} {
+	result := make(map[string]interface{}, len(target))
+	for k, v := range target {
+		result[k] = v
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+
+		if patchChild, ok := value.(map[string]interface{}); ok {
+			targetChild, _ := result[key].(map[string]interface{})
+			result[key] = mergePatch(targetChild, patchChild)
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// patchOp is a single RFC 6902 JSON Patch operation.
+type patchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// applyJSONPatch applies an ordered RFC 6902 JSON Patch document to a copy
+// of base, returning the patched document.
+func applyJSONPatch(base map[string]interface{}, rawOps []interface{}) (map[string]interface{}, error) {
+	doc, err := deepCopyViaJSON(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy base settings: %w", err)
+	}
+
+	for i, raw := range rawOps {
+		opBytes, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid patch operation %d: %w", i, err)
+		}
+		var op patchOp
+		if err := json.Unmarshal(opBytes, &op); err != nil {
+			return nil, fmt.Errorf("invalid patch operation %d: %w", i, err)
+		}
+
+		if doc, err = applyPatchOp(doc, op); err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	result, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patched document is not an object")
+	}
+	return result, nil
+}
+
+func applyPatchOp(doc interface{}, op patchOp) (interface{}, error) {
+	switch op.Op {
+	case "add":
+		return patchMutate(doc, op.Path, patchAdd(op.Value))
+	case "replace":
+		return patchMutate(doc, op.Path, patchReplace(op.Value))
+	case "remove":
+		return patchMutate(doc, op.Path, patchRemove())
+	case "move":
+		value, err := patchGet(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		doc, err = patchMutate(doc, op.From, patchRemove())
+		if err != nil {
+			return nil, err
+		}
+		return patchMutate(doc, op.Path, patchAdd(value))
+	case "copy":
+		value, err := patchGet(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return patchMutate(doc, op.Path, patchAdd(value))
+	case "test":
+		value, err := patchGet(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, op.Value) {
+			return nil, fmt.Errorf("test failed: value does not match")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+// pointerParts splits a JSON Pointer into its unescaped reference tokens.
+func pointerParts(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer: %q", path)
+	}
+	parts := strings.Split(path[1:], "/")
+	for i, p := range parts {
+		p = strings.ReplaceAll(p, "~1", "/")
+		p = strings.ReplaceAll(p, "~0", "~")
+		parts[i] = p
+	}
+	return parts, nil
+}
+
+// patchGet resolves path against doc.
+func patchGet(doc interface{}, path string) (interface{}, error) {
+	parts, err := pointerParts(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := doc
+	for _, p := range parts {
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			v, ok := node[p]
+			if !ok {
+				return nil, fmt.Errorf("no such key %q", p)
+			}
+			cur = v
+		case []interface{}:
+			idx, err := strconv.Atoi(p)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("invalid array index %q", p)
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("cannot traverse into a scalar at %q", p)
+		}
+	}
+	return cur, nil
+}
+
+// patchMutateFunc applies an in-place operation to a container (doc's
+// parent at the targeted path) given the final reference token, returning
+// the container's (possibly new, for arrays that grow or shrink) value.
+type patchMutateFunc func(container interface{}, key string) (interface{}, error)
+
+func patchAdd(value interface{}) patchMutateFunc {
+	return func(container interface{}, key string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			c[key] = value
+			return c, nil
+		case []interface{}:
+			idx := len(c)
+			if key != "-" {
+				var err error
+				idx, err = strconv.Atoi(key)
+				if err != nil || idx < 0 || idx > len(c) {
+					return nil, fmt.Errorf("invalid array index %q", key)
+				}
+			}
+			c = append(c, nil)
+			copy(c[idx+1:], c[idx:])
+			c[idx] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("cannot add into a scalar")
+		}
+	}
+}
+
+func patchReplace(value interface{}) patchMutateFunc {
+	return func(container interface{}, key string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			if _, ok := c[key]; !ok {
+				return nil, fmt.Errorf("no such key %q", key)
+			}
+			c[key] = value
+			return c, nil
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, fmt.Errorf("invalid array index %q", key)
+			}
+			c[idx] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("cannot replace into a scalar")
+		}
+	}
+}
+
+func patchRemove() patchMutateFunc {
+	return func(container interface{}, key string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			if _, ok := c[key]; !ok {
+				return nil, fmt.Errorf("no such key %q", key)
+			}
+			delete(c, key)
+			return c, nil
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, fmt.Errorf("invalid array index %q", key)
+			}
+			return append(c[:idx], c[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove from a scalar")
+		}
+	}
+}
+
+// patchMutate walks doc to path's parent container and applies mutate to
+// it, threading any container replacement (e.g. a shrunk/grown array) back
+// up to doc.
+func patchMutate(doc interface{}, path string, mutate patchMutateFunc) (interface{}, error) {
+	parts, err := pointerParts(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("cannot operate on the document root")
+	}
+	return patchMutateAt(doc, parts, mutate)
+}
+
+func patchMutateAt(container interface{}, parts []string, mutate patchMutateFunc) (interface{}, error) {
+	if len(parts) == 1 {
+		return mutate(container, parts[0])
+	}
+
+	switch node := container.(type) {
+	case map[string]interface{}:
+		child, ok := node[parts[0]]
+		if !ok {
+			return nil, fmt.Errorf("no such key %q", parts[0])
+		}
+		newChild, err := patchMutateAt(child, parts[1:], mutate)
+		if err != nil {
+			return nil, err
+		}
+		node[parts[0]] = newChild
+		return node, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(parts[0])
+		if err != nil || idx < 0 || idx >= len(node) {
+			return nil, fmt.Errorf("invalid array index %q", parts[0])
+		}
+		newChild, err := patchMutateAt(node[idx], parts[1:], mutate)
+		if err != nil {
+			return nil, err
+		}
+		node[idx] = newChild
+		return node, nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into a scalar at %q", parts[0])
+	}
+}
+
+// deepCopyViaJSON returns a deep copy of v via a JSON marshal/unmarshal
+// round-trip, so applyJSONPatch never mutates the caller's base map.
+func deepCopyViaJSON(v map[string]interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package operations
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeSettingsSmartUnionWithReplaceDirective(t *testing.T) {
+	base := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"pre-commit": "lint",
+		},
+	}
+	overlay := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"__strategy": "replace",
+			"pre-push":   "test",
+		},
+	}
+
+	merged, err := MergeSettings(base, overlay, MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+
+	hooks, ok := merged["hooks"].(map[string]interface{})
+	if !ok {
+		t.Fatal("hooks should be a map")
+	}
+	expected := map[string]interface{}{"pre-push": "test"}
+	if !reflect.DeepEqual(hooks, expected) {
+		t.Errorf("expected hooks to be replaced wholesale, got %v", hooks)
+	}
+}
+
+func TestMergeSettingsSmartUnionWithStrategiesOverride(t *testing.T) {
+	base := map[string]interface{}{
+		"permissions": []interface{}{"read"},
+	}
+	overlay := map[string]interface{}{
+		"permissions": []interface{}{"write"},
+		"_strategies": map[string]interface{}{
+			"/permissions": "replace",
+		},
+	}
+
+	merged, err := MergeSettings(base, overlay, MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+
+	if _, ok := merged["_strategies"]; ok {
+		t.Error("_strategies directive should be stripped from the result")
+	}
+
+	perms, ok := merged["permissions"].([]interface{})
+	if !ok {
+		t.Fatal("permissions should be a slice")
+	}
+	expected := []interface{}{"write"}
+	if !reflect.DeepEqual(perms, expected) {
+		t.Errorf("expected permissions to be replaced, got %v", perms)
+	}
+}
+
+func TestMergeSettingsRFC7396(t *testing.T) {
+	base := map[string]interface{}{
+		"title": "base",
+		"nested": map[string]interface{}{
+			"a": "1",
+			"b": "2",
+		},
+	}
+	overlay := map[string]interface{}{
+		"title": "overlay",
+		"nested": map[string]interface{}{
+			"b": nil,
+			"c": "3",
+		},
+	}
+
+	merged, err := MergeSettings(base, overlay, MergeOptions{Strategy: StrategyRFC7396})
+	if err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+
+	if merged["title"] != "overlay" {
+		t.Errorf("expected title to be overwritten, got %v", merged["title"])
+	}
+
+	nested, ok := merged["nested"].(map[string]interface{})
+	if !ok {
+		t.Fatal("nested should be a map")
+	}
+	if nested["a"] != "1" {
+		t.Error("nested.a should be preserved")
+	}
+	if _, ok := nested["b"]; ok {
+		t.Error("nested.b should be deleted by its null overlay value")
+	}
+	if nested["c"] != "3" {
+		t.Error("nested.c should be added")
+	}
+}
+
+func TestMergeSettingsRFC6902(t *testing.T) {
+	base := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"pre-commit": "lint",
+		},
+		"permissions": []interface{}{"read", "write"},
+	}
+
+	ops := []interface{}{
+		map[string]interface{}{"op": "add", "path": "/hooks/pre-push", "value": "test"},
+		map[string]interface{}{"op": "remove", "path": "/hooks/pre-commit"},
+		map[string]interface{}{"op": "replace", "path": "/permissions/0", "value": "admin"},
+	}
+
+	merged, err := MergeSettings(base, ops, MergeOptions{Strategy: StrategyRFC6902})
+	if err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+
+	hooks, ok := merged["hooks"].(map[string]interface{})
+	if !ok {
+		t.Fatal("hooks should be a map")
+	}
+	if _, ok := hooks["pre-commit"]; ok {
+		t.Error("pre-commit should have been removed")
+	}
+	if hooks["pre-push"] != "test" {
+		t.Errorf("expected pre-push to be added, got %v", hooks["pre-push"])
+	}
+
+	perms, ok := merged["permissions"].([]interface{})
+	if !ok {
+		t.Fatal("permissions should be a slice")
+	}
+	expected := []interface{}{"admin", "write"}
+	if !reflect.DeepEqual(perms, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, perms)
+	}
+
+	// base must not have been mutated by the patch.
+	basePerms := base["permissions"].([]interface{})
+	if basePerms[0] != "read" {
+		t.Error("applyJSONPatch should not mutate base")
+	}
+}
+
+func TestMergeSettingsReplace(t *testing.T) {
+	base := map[string]interface{}{"a": "1"}
+	overlay := map[string]interface{}{"b": "2"}
+
+	merged, err := MergeSettings(base, overlay, MergeOptions{Strategy: StrategyReplace})
+	if err != nil {
+		t.Fatalf("MergeSettings failed: %v", err)
+	}
+	if !reflect.DeepEqual(merged, overlay) {
+		t.Errorf("expected overlay to fully replace base, got %v", merged)
+	}
+}
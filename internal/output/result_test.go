@@ -18,16 +18,16 @@ func TestSuccess(t *testing.T) {
 	}
 }
 
-func TestError(t *testing.T) {
-	result := Error("test error")
+func TestErrorResult(t *testing.T) {
+	result := ErrorResult("test error")
 	if result.Success {
-		t.Error("Error() should return result with Success=false")
+		t.Error("ErrorResult() should return result with Success=false")
 	}
 	if result.Message != "test error" {
 		t.Errorf("Expected message 'test error', got %s", result.Message)
 	}
 	if result.Data != nil {
-		t.Error("Error() should not set Data")
+		t.Error("ErrorResult() should not set Data")
 	}
 }
 
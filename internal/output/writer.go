@@ -0,0 +1,153 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// currentFormat is set once from the root --output/-o flag in
+// PersistentPreRunE and read by CurrentWriter for the rest of the command's
+// lifetime.
+var currentFormat string
+
+// SetFormat sets the output format used by CurrentWriter. Called once from
+// the root command's PersistentPreRunE with the --output/-o flag value.
+func SetFormat(format string) {
+	currentFormat = format
+}
+
+// ValidateFormat reports an error if format isn't one New recognizes,
+// without needing an io.Writer to construct one against.
+func ValidateFormat(format string) error {
+	_, err := New(format, io.Discard)
+	return err
+}
+
+// CurrentWriter returns the Writer for the format set by SetFormat, writing
+// to stdout. An invalid format (should have already been rejected by the
+// root flag's validation) falls back to text.
+func CurrentWriter() Writer {
+	w, err := New(currentFormat, os.Stdout)
+	if err != nil {
+		return &textWriter{w: os.Stdout}
+	}
+	return w
+}
+
+// Writer renders a command's result in one of the formats selectable via
+// the root --output/-o flag. Commands build a typed value (e.g. StatusInfo,
+// a workspace create result map) and hand it to Emit along with a text
+// renderer; only one of them actually runs, depending on the format.
+type Writer interface {
+	// Emit writes v. In "json"/"yaml" mode it marshals v directly; in
+	// "text" mode it calls text(w) instead, so human-oriented formatting
+	// (fmt.Printf calls, color, etc.) only runs when it'll actually be
+	// read by a human.
+	Emit(v interface{}, text func(w io.Writer)) error
+
+	// Table writes a list of rows under headers. In "text" mode it prints
+	// an aligned, padded table; in "json"/"yaml" mode it emits an array of
+	// objects mapping each header to its row value.
+	Table(headers []string, rows [][]string) error
+}
+
+// New returns the Writer for format ("", "text", "json", or "yaml"),
+// writing to w.
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case "", "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "yaml":
+		return &yamlWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (must be text, json, or yaml)", format)
+	}
+}
+
+type textWriter struct {
+	w io.Writer
+}
+
+func (t *textWriter) Emit(_ interface{}, text func(w io.Writer)) error {
+	text(t.w)
+	return nil
+}
+
+func (t *textWriter) Table(headers []string, rows [][]string) error {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	writeRow := func(cells []string) {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = cell + strings.Repeat(" ", widths[i]-len(cell))
+		}
+		fmt.Fprintln(t.w, strings.Join(padded, "  "))
+	}
+
+	writeRow(headers)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return nil
+}
+
+type jsonWriter struct {
+	w io.Writer
+}
+
+func (j *jsonWriter) Emit(v interface{}, _ func(w io.Writer)) error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func (j *jsonWriter) Table(headers []string, rows [][]string) error {
+	return j.Emit(tableRecords(headers, rows), nil)
+}
+
+type yamlWriter struct {
+	w io.Writer
+}
+
+func (y *yamlWriter) Emit(v interface{}, _ func(w io.Writer)) error {
+	encoder := yaml.NewEncoder(y.w)
+	defer func() { _ = encoder.Close() }()
+	return encoder.Encode(v)
+}
+
+func (y *yamlWriter) Table(headers []string, rows [][]string) error {
+	return y.Emit(tableRecords(headers, rows), nil)
+}
+
+// tableRecords turns a headers/rows table into the array-of-objects shape
+// jsonWriter/yamlWriter emit for Table.
+func tableRecords(headers []string, rows [][]string) []map[string]string {
+	records := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		record := make(map[string]string, len(headers))
+		for j, h := range headers {
+			if j < len(row) {
+				record[h] = row[j]
+			}
+		}
+		records[i] = record
+	}
+	return records
+}
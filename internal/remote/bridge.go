@@ -0,0 +1,70 @@
+package remote
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// BridgeConfig records self-hosted forge hosts registered with `agentctl
+// bridge configure`, mapping each to the provider kind ("github", "gitlab",
+// or "gitea") that speaks to it. github.com and gitlab.com never need an
+// entry here; this is only for enterprise/self-hosted instances and
+// Gitea/Forgejo, which have no fixed domain to detect against.
+type BridgeConfig struct {
+	Hosts map[string]string `json:"hosts"`
+}
+
+// BridgePath returns the path to the bridge config under target.
+func BridgePath(target string) string {
+	return filepath.Join(target, ".claude", "agentctl-bridges.json")
+}
+
+// LoadBridgeConfig reads the bridge config, returning an empty one if none
+// exists yet.
+func LoadBridgeConfig(target string) (*BridgeConfig, error) {
+	data, err := os.ReadFile(BridgePath(target)) //nolint:gosec // Path is derived from the repo root
+	if os.IsNotExist(err) {
+		return &BridgeConfig{Hosts: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg BridgeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Hosts == nil {
+		cfg.Hosts = map[string]string{}
+	}
+	return &cfg, nil
+}
+
+// SaveBridgeConfig writes cfg to the bridge config under target.
+func SaveBridgeConfig(target string, cfg *BridgeConfig) error {
+	if err := os.MkdirAll(filepath.Dir(BridgePath(target)), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(BridgePath(target), append(data, '\n'), 0644) //nolint:gosec // Bridge config needs to be readable
+}
+
+// ProviderForHost returns the provider registered for host via `agentctl
+// bridge configure`, or nil if host isn't registered.
+func ProviderForHost(cfg *BridgeConfig, host string) Provider {
+	switch cfg.Hosts[host] {
+	case "github":
+		return &GitHubProvider{}
+	case "gitlab":
+		return &GitLabProvider{}
+	case "gitea":
+		return &GiteaProvider{Host: host}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,247 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// giteaTokenEnv is the environment variable GiteaProvider reads its
+// personal access token from.
+const giteaTokenEnv = "GITEA_TOKEN"
+
+// GiteaProvider integrates with a self-hosted Gitea/Forgejo instance's
+// pull requests via its REST v1 API (which closely mirrors GitHub's),
+// authenticating with a personal access token read from GITEA_TOKEN.
+// Unlike GitHubProvider/GitLabProvider, its host isn't fixed: Detect only
+// matches hosts registered with `agentctl bridge configure`.
+type GiteaProvider struct {
+	// Host is the Gitea instance's hostname, e.g. "git.example.com".
+	Host string
+}
+
+// Detect reports whether repoRoot's origin remote points at p.Host.
+func (p *GiteaProvider) Detect(repoRoot string) bool {
+	u, err := originURL(repoRoot)
+	if err != nil {
+		return false
+	}
+	_, _, ok := parseOwnerRepo(u, p.Host)
+	return ok
+}
+
+func (p *GiteaProvider) ownerRepo(repoRoot string) (string, string, error) {
+	u, err := originURL(repoRoot)
+	if err != nil {
+		return "", "", err
+	}
+	owner, repoName, ok := parseOwnerRepo(u, p.Host)
+	if !ok {
+		return "", "", fmt.Errorf("failed to parse %s owner/repo from %s", p.Host, u)
+	}
+	return owner, repoName, nil
+}
+
+func (p *GiteaProvider) do(ctx context.Context, method, path string, body io.Reader, out interface{}) error {
+	token := os.Getenv(giteaTokenEnv)
+	if token == "" {
+		return fmt.Errorf("%s is not set; Gitea integration requires a personal access token", giteaTokenEnv)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("https://%s/api/v1/%s", p.Host, path), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Gitea API returned %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type giteaPR struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+}
+
+func (pr giteaPR) toPR() PR {
+	return PR{Number: pr.Number, URL: pr.HTMLURL, Title: pr.Title, State: pr.State}
+}
+
+// OpenPullRequest opens a new Gitea pull request for opts.Branch against
+// opts.Base.
+func (p *GiteaProvider) OpenPullRequest(ctx context.Context, repoRoot string, opts PROptions) (*PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	body := jsonReader(map[string]interface{}{
+		"head":  opts.Branch,
+		"base":  opts.Base,
+		"title": opts.Title,
+		"body":  opts.Body,
+	})
+
+	var pr giteaPR
+	path := fmt.Sprintf("repos/%s/%s/pulls", owner, repoName)
+	if err := p.do(ctx, http.MethodPost, path, body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	result := pr.toPR()
+	return &result, nil
+}
+
+// GetPullRequest returns the open pull request for branch, if any.
+func (p *GiteaProvider) GetPullRequest(ctx context.Context, repoRoot string, branch string) (*PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []giteaPR
+	path := fmt.Sprintf("repos/%s/%s/pulls?state=open&head=%s", owner, repoName, url.QueryEscape(branch))
+	if err := p.do(ctx, http.MethodGet, path, nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to query pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, fmt.Errorf("no open pull request found for branch %s", branch)
+	}
+
+	result := prs[0].toPR()
+	return &result, nil
+}
+
+// ListPullRequests lists open pull requests for the repository.
+func (p *GiteaProvider) ListPullRequests(ctx context.Context, repoRoot string) ([]PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []giteaPR
+	path := fmt.Sprintf("repos/%s/%s/pulls?state=open", owner, repoName)
+	if err := p.do(ctx, http.MethodGet, path, nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	result := make([]PR, len(prs))
+	for i, pr := range prs {
+		result[i] = pr.toPR()
+	}
+	return result, nil
+}
+
+// GetChecks summarizes commit status state for branch's current HEAD.
+func (p *GiteaProvider) GetChecks(ctx context.Context, repoRoot, branch string) (string, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	sha, err := branchHeadSHA(repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	var statuses []struct {
+		Status string `json:"status"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/commits/%s/statuses", owner, repoName, sha)
+	if err := p.do(ctx, http.MethodGet, path, nil, &statuses); err != nil {
+		return "", fmt.Errorf("failed to query commit statuses: %w", err)
+	}
+
+	var passed, failed, pending int
+	for _, s := range statuses {
+		switch s.Status {
+		case "success":
+			passed++
+		case "failure", "error":
+			failed++
+		case "pending":
+			pending++
+		}
+	}
+	return summarizeChecks(passed, failed, pending), nil
+}
+
+// GetReviewDecision summarizes the review state of the open pull request
+// for branch.
+func (p *GiteaProvider) GetReviewDecision(ctx context.Context, repoRoot, branch string) (string, error) {
+	pr, err := p.GetPullRequest(ctx, repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return "", err
+	}
+
+	var reviews []struct {
+		State string `json:"state"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repoName, pr.Number)
+	if err := p.do(ctx, http.MethodGet, path, nil, &reviews); err != nil {
+		return "", fmt.Errorf("failed to query reviews: %w", err)
+	}
+
+	approved := false
+	for _, r := range reviews {
+		switch r.State {
+		case "REQUEST_CHANGES":
+			return "CHANGES_REQUESTED", nil
+		case "APPROVED":
+			approved = true
+		}
+	}
+	if approved {
+		return "APPROVED", nil
+	}
+	return "REVIEW_REQUIRED", nil
+}
+
+// ListNotifications lists unread notifications for this repository.
+func (p *GiteaProvider) ListNotifications(ctx context.Context, repoRoot string) ([]Notification, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		ID      int64 `json:"id"`
+		Subject struct {
+			Title string `json:"title"`
+			URL   string `json:"html_url"`
+			Type  string `json:"type"`
+		} `json:"subject"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/notifications", owner, repoName)
+	if err := p.do(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	result := make([]Notification, len(raw))
+	for i, n := range raw {
+		result[i] = Notification{ID: fmt.Sprintf("%d", n.ID), Title: n.Subject.Title, URL: n.Subject.URL, Reason: n.Subject.Type}
+	}
+	return result, nil
+}
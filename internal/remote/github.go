@@ -0,0 +1,255 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// GitHubProvider integrates with GitHub pull requests using gh CLI
+// authentication.
+type GitHubProvider struct{}
+
+// Detect reports whether repoRoot's origin remote points at github.com.
+func (p *GitHubProvider) Detect(repoRoot string) bool {
+	url, err := originURL(repoRoot)
+	if err != nil {
+		return false
+	}
+	_, _, ok := parseOwnerRepo(url, "github.com")
+	return ok
+}
+
+func (p *GitHubProvider) ownerRepo(repoRoot string) (string, string, error) {
+	url, err := originURL(repoRoot)
+	if err != nil {
+		return "", "", err
+	}
+	owner, repoName, ok := parseOwnerRepo(url, "github.com")
+	if !ok {
+		return "", "", fmt.Errorf("failed to parse GitHub owner/repo from %s", url)
+	}
+	return owner, repoName, nil
+}
+
+// OpenPullRequest opens a new GitHub pull request for opts.Branch against
+// opts.Base.
+func (p *GitHubProvider) OpenPullRequest(_ context.Context, repoRoot string, opts PROptions) (*PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	body := map[string]interface{}{
+		"title": opts.Title,
+		"body":  opts.Body,
+		"head":  opts.Branch,
+		"base":  opts.Base,
+		"draft": opts.Draft,
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls", owner, repoName)
+	if err := client.Post(path, jsonReader(body), &resp); err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	return &PR{Number: resp.Number, URL: resp.HTMLURL, Title: resp.Title, State: resp.State}, nil
+}
+
+// GetPullRequest returns the open pull request for branch, if any.
+func (p *GitHubProvider) GetPullRequest(_ context.Context, repoRoot string, branch string) (*PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	var prs []struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls?head=%s:%s&state=open", owner, repoName, owner, branch)
+	if err := client.Get(path, &prs); err != nil {
+		return nil, fmt.Errorf("failed to query pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, fmt.Errorf("no open pull request found for branch %s", branch)
+	}
+
+	pr := prs[0]
+	return &PR{Number: pr.Number, URL: pr.HTMLURL, Title: pr.Title, State: pr.State}, nil
+}
+
+// ListPullRequests lists open pull requests for the repository.
+func (p *GitHubProvider) ListPullRequests(_ context.Context, repoRoot string) ([]PR, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	var prs []struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls?state=open", owner, repoName)
+	if err := client.Get(path, &prs); err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	result := make([]PR, len(prs))
+	for i, pr := range prs {
+		result[i] = PR{Number: pr.Number, URL: pr.HTMLURL, Title: pr.Title, State: pr.State}
+	}
+	return result, nil
+}
+
+// GetChecks summarizes check-run status for branch's current HEAD commit.
+func (p *GitHubProvider) GetChecks(_ context.Context, repoRoot, branch string) (string, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	sha, err := branchHeadSHA(repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	var resp struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repoName, sha)
+	if err := client.Get(path, &resp); err != nil {
+		return "", fmt.Errorf("failed to query check runs: %w", err)
+	}
+
+	var passed, failed, pending int
+	for _, run := range resp.CheckRuns {
+		switch {
+		case run.Status != "completed":
+			pending++
+		case run.Conclusion == "success":
+			passed++
+		case run.Conclusion == "failure" || run.Conclusion == "cancelled":
+			failed++
+		}
+	}
+	return summarizeChecks(passed, failed, pending), nil
+}
+
+// GetReviewDecision summarizes the review state of the open pull request
+// for branch. The REST API has no single "review decision" field (that's a
+// GraphQL-only convenience gh CLI computes), so this walks the review list
+// itself: any CHANGES_REQUESTED wins, otherwise any APPROVED, otherwise the
+// PR is still awaiting review.
+func (p *GitHubProvider) GetReviewDecision(ctx context.Context, repoRoot, branch string) (string, error) {
+	pr, err := p.GetPullRequest(ctx, repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	var reviews []struct {
+		State string `json:"state"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repoName, pr.Number)
+	if err := client.Get(path, &reviews); err != nil {
+		return "", fmt.Errorf("failed to query reviews: %w", err)
+	}
+
+	approved := false
+	for _, r := range reviews {
+		switch r.State {
+		case "CHANGES_REQUESTED":
+			return "CHANGES_REQUESTED", nil
+		case "APPROVED":
+			approved = true
+		}
+	}
+	if approved {
+		return "APPROVED", nil
+	}
+	return "REVIEW_REQUIRED", nil
+}
+
+// ListNotifications lists unread GitHub notifications for this repository.
+func (p *GitHubProvider) ListNotifications(_ context.Context, repoRoot string) ([]Notification, error) {
+	owner, repoName, err := p.ownerRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub API client: %w", err)
+	}
+
+	var raw []struct {
+		ID      string `json:"id"`
+		Reason  string `json:"reason"`
+		Subject struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+		} `json:"subject"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := client.Get("notifications", &raw); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	fullName := owner + "/" + repoName
+	var result []Notification
+	for _, n := range raw {
+		if n.Repository.FullName != fullName {
+			continue
+		}
+		result = append(result, Notification{ID: n.ID, Title: n.Subject.Title, URL: n.Subject.URL, Reason: n.Reason})
+	}
+	return result, nil
+}
@@ -0,0 +1,212 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// gitLabTokenEnv is the environment variable GitLabProvider reads its
+// personal access token from.
+const gitLabTokenEnv = "GITLAB_TOKEN"
+
+// GitLabProvider integrates with GitLab merge requests via the REST v4 API,
+// authenticating with a personal access token read from GITLAB_TOKEN.
+type GitLabProvider struct{}
+
+// Detect reports whether repoRoot's origin remote points at gitlab.com.
+func (p *GitLabProvider) Detect(repoRoot string) bool {
+	u, err := originURL(repoRoot)
+	if err != nil {
+		return false
+	}
+	_, _, ok := parseOwnerRepo(u, "gitlab.com")
+	return ok
+}
+
+func (p *GitLabProvider) projectPath(repoRoot string) (string, error) {
+	u, err := originURL(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	owner, repoName, ok := parseOwnerRepo(u, "gitlab.com")
+	if !ok {
+		return "", fmt.Errorf("failed to parse GitLab owner/repo from %s", u)
+	}
+	return url.PathEscape(owner + "/" + repoName), nil
+}
+
+func (p *GitLabProvider) do(ctx context.Context, method, path string, body io.Reader, out interface{}) error {
+	token := os.Getenv(gitLabTokenEnv)
+	if token == "" {
+		return fmt.Errorf("%s is not set; GitLab integration requires a personal access token", gitLabTokenEnv)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "https://gitlab.com/api/v4/"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitLab API returned %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type gitlabMR struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+func (mr gitlabMR) toPR() PR {
+	return PR{Number: mr.IID, URL: mr.WebURL, Title: mr.Title, State: mr.State}
+}
+
+// OpenPullRequest opens a new GitLab merge request for opts.Branch against
+// opts.Base.
+func (p *GitLabProvider) OpenPullRequest(ctx context.Context, repoRoot string, opts PROptions) (*PR, error) {
+	project, err := p.projectPath(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	body := jsonReader(map[string]interface{}{
+		"source_branch": opts.Branch,
+		"target_branch": opts.Base,
+		"title":         opts.Title,
+		"description":   opts.Body,
+	})
+
+	var mr gitlabMR
+	path := fmt.Sprintf("projects/%s/merge_requests", project)
+	if err := p.do(ctx, http.MethodPost, path, body, &mr); err != nil {
+		return nil, fmt.Errorf("failed to open merge request: %w", err)
+	}
+
+	pr := mr.toPR()
+	return &pr, nil
+}
+
+// GetPullRequest returns the open merge request for branch, if any.
+func (p *GitLabProvider) GetPullRequest(ctx context.Context, repoRoot string, branch string) (*PR, error) {
+	project, err := p.projectPath(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []gitlabMR
+	path := fmt.Sprintf("projects/%s/merge_requests?source_branch=%s&state=opened", project, url.QueryEscape(branch))
+	if err := p.do(ctx, http.MethodGet, path, nil, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to query merge requests: %w", err)
+	}
+	if len(mrs) == 0 {
+		return nil, fmt.Errorf("no open merge request found for branch %s", branch)
+	}
+
+	pr := mrs[0].toPR()
+	return &pr, nil
+}
+
+// ListPullRequests lists open merge requests for the repository.
+func (p *GitLabProvider) ListPullRequests(ctx context.Context, repoRoot string) ([]PR, error) {
+	project, err := p.projectPath(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []gitlabMR
+	path := fmt.Sprintf("projects/%s/merge_requests?state=opened", project)
+	if err := p.do(ctx, http.MethodGet, path, nil, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+
+	result := make([]PR, len(mrs))
+	for i, mr := range mrs {
+		result[i] = mr.toPR()
+	}
+	return result, nil
+}
+
+// GetChecks summarizes commit status state for branch's current HEAD.
+func (p *GitLabProvider) GetChecks(ctx context.Context, repoRoot, branch string) (string, error) {
+	project, err := p.projectPath(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	sha, err := branchHeadSHA(repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	var statuses []struct {
+		Status string `json:"status"`
+	}
+	path := fmt.Sprintf("projects/%s/repository/commits/%s/statuses", project, sha)
+	if err := p.do(ctx, http.MethodGet, path, nil, &statuses); err != nil {
+		return "", fmt.Errorf("failed to query commit statuses: %w", err)
+	}
+
+	var passed, failed, pending int
+	for _, s := range statuses {
+		switch s.Status {
+		case "success":
+			passed++
+		case "failed", "canceled":
+			failed++
+		case "pending", "running", "created":
+			pending++
+		}
+	}
+	return summarizeChecks(passed, failed, pending), nil
+}
+
+// GetReviewDecision summarizes the approval state of the open merge
+// request for branch.
+func (p *GitLabProvider) GetReviewDecision(ctx context.Context, repoRoot, branch string) (string, error) {
+	pr, err := p.GetPullRequest(ctx, repoRoot, branch)
+	if err != nil {
+		return "", err
+	}
+
+	project, err := p.projectPath(repoRoot)
+	if err != nil {
+		return "", err
+	}
+
+	var approvals struct {
+		ApprovalsLeft int `json:"approvals_left"`
+	}
+	path := fmt.Sprintf("projects/%s/merge_requests/%d/approvals", project, pr.Number)
+	if err := p.do(ctx, http.MethodGet, path, nil, &approvals); err != nil {
+		return "", fmt.Errorf("failed to query approvals: %w", err)
+	}
+	if approvals.ApprovalsLeft > 0 {
+		return "REVIEW_REQUIRED", nil
+	}
+	return "APPROVED", nil
+}
+
+// ListNotifications is not implemented for GitLab: its closest analog (the
+// personal to-do list) isn't scoped per-repository the way GitHub/Gitea
+// notifications are, so there's no faithful per-repo equivalent to return
+// here yet.
+func (p *GitLabProvider) ListNotifications(_ context.Context, _ string) ([]Notification, error) {
+	return nil, fmt.Errorf("listing notifications is not supported for GitLab yet")
+}
@@ -0,0 +1,225 @@
+// Package remote provides pluggable integration with hosted Git forges
+// (GitHub, GitLab, ...) for opening and querying pull/merge requests from a
+// workspace.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// PR is a provider-agnostic view of an open pull/merge request.
+type PR struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// Notification is a provider-agnostic view of an unread notification.
+type Notification struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Reason string `json:"reason"`
+}
+
+// PROptions describes a pull/merge request to open.
+type PROptions struct {
+	Branch string
+	Base   string
+	Title  string
+	Body   string
+	Draft  bool
+}
+
+// Provider integrates with a hosted Git forge to open and query pull/merge
+// requests.
+type Provider interface {
+	// Detect reports whether this provider's forge hosts the repository at
+	// repoRoot, based on the `origin` remote URL.
+	Detect(repoRoot string) bool
+	// OpenPullRequest opens a new pull/merge request and returns it.
+	OpenPullRequest(ctx context.Context, repoRoot string, opts PROptions) (*PR, error)
+	// GetPullRequest fetches the open pull/merge request for branch, if any.
+	GetPullRequest(ctx context.Context, repoRoot string, branch string) (*PR, error)
+	// ListPullRequests lists open pull/merge requests for the repository.
+	ListPullRequests(ctx context.Context, repoRoot string) ([]PR, error)
+	// GetChecks summarizes CI check status for branch's current HEAD.
+	GetChecks(ctx context.Context, repoRoot string, branch string) (string, error)
+	// GetReviewDecision summarizes the review state of the open
+	// pull/merge request for branch, if any.
+	GetReviewDecision(ctx context.Context, repoRoot string, branch string) (string, error)
+	// ListNotifications lists unread notifications relevant to the
+	// repository.
+	ListNotifications(ctx context.Context, repoRoot string) ([]Notification, error)
+}
+
+// providers is the set of providers consulted by Detect, in priority order.
+var providers = []Provider{
+	&GitHubProvider{},
+	&GitLabProvider{},
+}
+
+// ErrNoProvider is returned when no registered provider recognizes the
+// repository's origin remote.
+var ErrNoProvider = fmt.Errorf("no remote provider detected for this repository")
+
+// DetectProvider returns the provider for repoRoot's origin remote: first
+// any self-hosted host registered via `agentctl bridge configure` (covers
+// GitHub Enterprise, self-hosted GitLab, and Gitea/Forgejo, none of which
+// have a fixed domain to detect against), then the built-in
+// github.com/gitlab.com detectors.
+func DetectProvider(repoRoot string) (Provider, error) {
+	if host, ok := originHost(repoRoot); ok {
+		if cfg, err := LoadBridgeConfig(repoRoot); err == nil {
+			if p := ProviderForHost(cfg, host); p != nil {
+				return p, nil
+			}
+		}
+	}
+
+	for _, p := range providers {
+		if p.Detect(repoRoot) {
+			return p, nil
+		}
+	}
+	return nil, ErrNoProvider
+}
+
+// ProviderByName returns a provider by explicit name ("github", "gitlab",
+// or "gitea"), bypassing Detect. For "gitea" it resolves the instance host
+// from repoRoot's origin remote. Used for the `--provider`/`--platform` CLI
+// overrides.
+func ProviderByName(name, repoRoot string) (Provider, error) {
+	switch strings.ToLower(name) {
+	case "github":
+		return &GitHubProvider{}, nil
+	case "gitlab":
+		return &GitLabProvider{}, nil
+	case "gitea":
+		host, ok := originHost(repoRoot)
+		if !ok {
+			return nil, fmt.Errorf("failed to determine origin host for Gitea provider")
+		}
+		return &GiteaProvider{Host: host}, nil
+	default:
+		return nil, fmt.Errorf("unknown remote provider: %s", name)
+	}
+}
+
+// jsonReader marshals v and returns it as an io.Reader suitable for an API
+// client's request body.
+func jsonReader(v interface{}) io.Reader {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return bytes.NewReader(nil)
+	}
+	return bytes.NewReader(data)
+}
+
+// originURL returns the `origin` remote's URL for repoRoot.
+func originURL(repoRoot string) (string, error) {
+	repo, err := git.OpenRepo(context.Background(), repoRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return "", fmt.Errorf("no origin remote found: %w", err)
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("origin remote has no URL")
+	}
+	return urls[0], nil
+}
+
+// originHost extracts the host portion of repoRoot's origin remote URL,
+// e.g. "git.example.com" from both "git@git.example.com:owner/repo.git"
+// and "https://git.example.com/owner/repo".
+func originHost(repoRoot string) (string, bool) {
+	u, err := originURL(repoRoot)
+	if err != nil {
+		return "", false
+	}
+	u = strings.TrimSuffix(u, ".git")
+
+	if strings.HasPrefix(u, "git@") {
+		rest := strings.TrimPrefix(u, "git@")
+		if idx := strings.Index(rest, ":"); idx >= 0 {
+			return rest[:idx], true
+		}
+		return "", false
+	}
+
+	if parsed, err := neturl.Parse(u); err == nil && parsed.Host != "" {
+		return parsed.Host, true
+	}
+	return "", false
+}
+
+// branchHeadSHA resolves branch's local commit hash in repoRoot, used to
+// query CI check status for the same commit a PR's head points at.
+func branchHeadSHA(repoRoot, branch string) (string, error) {
+	repo, err := git.OpenRepo(context.Background(), repoRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve branch %s: %w", branch, err)
+	}
+	return ref.Hash().String(), nil
+}
+
+// summarizeChecks renders a passed/failed/pending check-run tally the same
+// way across providers.
+func summarizeChecks(passed, failed, pending int) string {
+	switch {
+	case failed > 0:
+		return fmt.Sprintf("%d failing", failed)
+	case pending > 0:
+		return fmt.Sprintf("%d pending", pending)
+	case passed > 0:
+		return fmt.Sprintf("%d passed", passed)
+	default:
+		return "no checks"
+	}
+}
+
+// parseOwnerRepo extracts owner/repo from an https or ssh remote URL for the
+// given host (e.g. "github.com", "gitlab.com").
+func parseOwnerRepo(url, host string) (owner, repo string, ok bool) {
+	url = strings.TrimSuffix(url, ".git")
+
+	sshPrefix := "git@" + host + ":"
+	if strings.HasPrefix(url, sshPrefix) {
+		parts := strings.SplitN(strings.TrimPrefix(url, sshPrefix), "/", 2)
+		if len(parts) == 2 {
+			return parts[0], parts[1], true
+		}
+		return "", "", false
+	}
+
+	marker := host + "/"
+	if idx := strings.Index(url, marker); idx >= 0 {
+		parts := strings.SplitN(url[idx+len(marker):], "/", 2)
+		if len(parts) == 2 {
+			return parts[0], parts[1], true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,105 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// ErrUnknownSink is returned by Config.Sink for an unrecognized SinkName.
+var ErrUnknownSink = fmt.Errorf("unknown error reporting sink")
+
+// Config controls how captured Entries are dispatched. It's loaded from
+// settings.json's "errorReporting" block, which config.Merge combines
+// across the user (~/.claude/settings.json) and project
+// (<repoRoot>/.claude/settings.json) scopes like every other agentctl
+// setting.
+type Config struct {
+	SinkName    string   `json:"sink"`              // "file" (default), "http", or "sentry"
+	Target      string   `json:"target"`            // file path, webhook URL, or Sentry DSN
+	Release     string   `json:"release,omitempty"` // tagged on sentry envelopes
+	Environment string   `json:"environment,omitempty"`
+	RedactKeys  []string `json:"redactKeys,omitempty"`
+}
+
+// defaultErrorLogPath is FileSink's target when Config doesn't set one.
+func defaultErrorLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "errors.log"
+	}
+	return filepath.Join(home, ".claude", "errors.log")
+}
+
+// LoadConfig reads the "errorReporting" block from user settings, merged
+// with project settings when ctx resolves to a git repository. Any read or
+// parse failure yields the zero Config (SinkName "" resolves to FileSink at
+// its default path), since error reporting must never itself block a hook.
+func LoadConfig(ctx context.Context) Config {
+	settings := map[string]interface{}{}
+
+	if data, err := os.ReadFile(userSettingsPath()); err == nil {
+		if parsed, err := config.LoadJSON(data); err == nil {
+			settings = parsed
+		}
+	}
+
+	if repoRoot, err := git.GetRepoRoot(ctx); err == nil {
+		if data, err := os.ReadFile(projectSettingsPath(repoRoot)); err == nil {
+			if parsed, err := config.LoadJSON(data); err == nil {
+				settings = config.Merge(settings, parsed)
+			}
+		}
+	}
+
+	raw, ok := settings["errorReporting"].(map[string]interface{})
+	if !ok {
+		return Config{}
+	}
+
+	data, err := config.SaveJSON(raw)
+	if err != nil {
+		return Config{}
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}
+	}
+	return cfg
+}
+
+func userSettingsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".claude", "settings.json")
+}
+
+func projectSettingsPath(repoRoot string) string {
+	return filepath.Join(repoRoot, ".claude", "settings.json")
+}
+
+// Sink resolves cfg to the configured Sink implementation.
+func (cfg Config) Sink() (Sink, error) {
+	switch cfg.SinkName {
+	case "", "file":
+		target := cfg.Target
+		if target == "" {
+			target = defaultErrorLogPath()
+		}
+		return FileSink{Path: target}, nil
+	case "http":
+		return HTTPSink{URL: cfg.Target}, nil
+	case "sentry":
+		return SentrySink{DSN: cfg.Target, Release: cfg.Release, Environment: cfg.Environment}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSink, cfg.SinkName)
+	}
+}
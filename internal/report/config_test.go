@@ -0,0 +1,59 @@
+package report
+
+import "testing"
+
+func TestConfigSinkResolvesEachSinkName(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      Config
+		wantType interface{}
+	}{
+		{"default", Config{}, FileSink{}},
+		{"file", Config{SinkName: "file", Target: "/tmp/errors.log"}, FileSink{}},
+		{"http", Config{SinkName: "http", Target: "https://example.com/hook"}, HTTPSink{}},
+		{"sentry", Config{SinkName: "sentry", Target: "https://key@o1.ingest.sentry.io/2"}, SentrySink{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, err := tc.cfg.Sink()
+			if err != nil {
+				t.Fatalf("Sink() failed: %v", err)
+			}
+			switch tc.wantType.(type) {
+			case FileSink:
+				if _, ok := sink.(FileSink); !ok {
+					t.Errorf("expected a FileSink, got %T", sink)
+				}
+			case HTTPSink:
+				if _, ok := sink.(HTTPSink); !ok {
+					t.Errorf("expected an HTTPSink, got %T", sink)
+				}
+			case SentrySink:
+				if _, ok := sink.(SentrySink); !ok {
+					t.Errorf("expected a SentrySink, got %T", sink)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigSinkDefaultsFileTargetWhenUnset(t *testing.T) {
+	sink, err := Config{}.Sink()
+	if err != nil {
+		t.Fatalf("Sink() failed: %v", err)
+	}
+	fileSink, ok := sink.(FileSink)
+	if !ok {
+		t.Fatalf("expected a FileSink, got %T", sink)
+	}
+	if fileSink.Path == "" {
+		t.Error("expected a non-empty default path")
+	}
+}
+
+func TestConfigSinkReturnsErrorForUnknownSink(t *testing.T) {
+	if _, err := (Config{SinkName: "carrier-pigeon"}).Sink(); err == nil {
+		t.Fatal("expected an error for an unknown sink name")
+	}
+}
@@ -0,0 +1,98 @@
+// Package report captures hook panics and errors — with stack, hook name,
+// matcher, tool name, and the raw stdin payload — and dispatches them to a
+// configurable sink, so flaky hooks are observable without each hook
+// implementation growing its own telemetry code.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+// Entry is a single captured hook failure.
+type Entry struct {
+	Time    time.Time       `json:"time"`
+	Hook    string          `json:"hook"`
+	Matcher string          `json:"matcher,omitempty"`
+	Tool    string          `json:"tool,omitempty"`
+	Error   string          `json:"error"`
+	Stack   string          `json:"stack,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Wrap returns a cobra RunE that runs next, capturing any panic as an Entry
+// and dispatching it to the configured sink before converting it into a
+// returned error, so the process exits through main's normal os.Exit(1)
+// path instead of whatever exit code an unrecovered panic happens to
+// produce. A non-nil error returned by next is captured the same way and
+// then passed through unchanged.
+//
+// This only sees panics and returned errors: hook RunE functions that
+// already handle an error by calling os.Exit directly (the established
+// pattern in this package) exit before control returns here, so Wrap never
+// changes their exit code — existing hook semantics are preserved exactly.
+func Wrap(hookName string, next func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				capture(cmd.Context(), Entry{
+					Time:    time.Now(),
+					Hook:    hookName,
+					Error:   fmt.Sprintf("panic: %v", r),
+					Stack:   string(debug.Stack()),
+					Payload: currentPayload(),
+				})
+				err = fmt.Errorf("%s: %v", hookName, r)
+			}
+		}()
+
+		err = next(cmd, args)
+		if err != nil {
+			capture(cmd.Context(), Entry{
+				Time:    time.Now(),
+				Hook:    hookName,
+				Error:   err.Error(),
+				Payload: currentPayload(),
+			})
+		}
+		return err
+	}
+}
+
+// currentPayload returns the raw stdin bytes the wrapped hook read via
+// hook.GetStdinData, if any, so a captured Entry can include the payload it
+// failed on without Wrap reading stdin a second time itself.
+func currentPayload() json.RawMessage {
+	data := hook.LastStdinPayload()
+	if len(data) == 0 {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+// capture loads the configured Config for ctx and sends entry to it,
+// swallowing any error: a broken error-reporting sink must never fail the
+// hook whose error it was trying to report. Callers that want to handle a
+// send failure themselves (e.g. `hook report-panic`) should call Capture
+// directly instead.
+func capture(ctx context.Context, entry Entry) {
+	_ = Capture(ctx, LoadConfig(ctx), entry)
+}
+
+// Capture redacts entry.Payload per cfg.RedactKeys and sends it to cfg's
+// configured sink.
+func Capture(ctx context.Context, cfg Config, entry Entry) error {
+	entry.Payload = redact(entry.Payload, cfg.RedactKeys)
+
+	sink, err := cfg.Sink()
+	if err != nil {
+		return err
+	}
+	return sink.Send(ctx, entry)
+}
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Sink dispatches a captured Entry somewhere a team can observe it: a local
+// file, a webhook, or an error-tracking service.
+type Sink interface {
+	Send(ctx context.Context, entry Entry) error
+}
+
+// FileSink appends each Entry as a line of JSONL to Path, creating its
+// parent directory if needed.
+type FileSink struct {
+	Path string
+}
+
+// Send implements Sink.
+func (s FileSink) Send(_ context.Context, entry Entry) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil { //nolint:gosec // Error log directory needs to be readable
+		return fmt.Errorf("failed to create error log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644) //nolint:gosec // Error log needs to be readable
+	if err != nil {
+		return fmt.Errorf("failed to open error log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// HTTPSink POSTs each Entry as JSON to URL.
+type HTTPSink struct {
+	URL string
+}
+
+// Send implements Sink.
+func (s HTTPSink) Send(ctx context.Context, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SentrySink POSTs each Entry to a Sentry DSN as a minimal envelope, tagged
+// with Release and Environment.
+type SentrySink struct {
+	DSN         string
+	Release     string
+	Environment string
+}
+
+// Send implements Sink.
+func (s SentrySink) Send(ctx context.Context, entry Entry) error {
+	endpoint, err := sentryEnvelopeEndpoint(s.DSN)
+	if err != nil {
+		return fmt.Errorf("failed to parse Sentry DSN: %w", err)
+	}
+
+	envelope, err := sentryEnvelope(entry, s.Release, s.Environment)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		return fmt.Errorf("failed to build Sentry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Sentry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sentryEnvelopeEndpoint derives a DSN's envelope ingest URL:
+// https://<key>@<host>/<project> -> https://<host>/api/<project>/envelope/.
+func sentryEnvelopeEndpoint(dsn string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	project := strings.TrimPrefix(u.Path, "/")
+	if project == "" {
+		return "", fmt.Errorf("DSN %q has no project ID", dsn)
+	}
+	return fmt.Sprintf("%s://%s/api/%s/envelope/", u.Scheme, u.Host, project), nil
+}
+
+// sentryEventID generates a random 32-character hex event ID, the format
+// Sentry's envelope protocol expects.
+func sentryEventID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// sentryEnvelope builds a minimal Sentry envelope: a header line followed by
+// one "event" item describing entry as an error-level message event.
+func sentryEnvelope(entry Entry, release, environment string) ([]byte, error) {
+	eventID := sentryEventID()
+	header := map[string]interface{}{"event_id": eventID, "sent_at": entry.Time.UTC().Format(time.RFC3339)}
+
+	event := map[string]interface{}{
+		"event_id":    eventID,
+		"timestamp":   entry.Time.UTC().Format(time.RFC3339),
+		"level":       "error",
+		"message":     map[string]interface{}{"formatted": entry.Error},
+		"release":     release,
+		"environment": environment,
+		"tags": map[string]interface{}{
+			"hook":    entry.Hook,
+			"matcher": entry.Matcher,
+			"tool":    entry.Tool,
+		},
+		"extra": map[string]interface{}{
+			"stack":   entry.Stack,
+			"payload": json.RawMessage(entry.Payload),
+		},
+	}
+
+	itemHeader := map[string]interface{}{"type": "event"}
+
+	var buf bytes.Buffer
+	for _, line := range []interface{}{header, itemHeader, event} {
+		data, err := json.Marshal(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Sentry envelope: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// redact walks payload (a JSON object) and replaces the value of any key in
+// keys (case-sensitive, matched at any nesting depth) with "[redacted]"
+// before a captured Entry is sent to a sink.
+func redact(payload json.RawMessage, keys []string) json.RawMessage {
+	if len(payload) == 0 || len(keys) == 0 {
+		return payload
+	}
+
+	redactSet := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		redactSet[k] = true
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		return payload
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, redactSet))
+	if err != nil {
+		return payload
+	}
+	return redacted
+}
+
+func redactValue(v interface{}, keys map[string]bool) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			if keys[k] {
+				out[k] = "[redacted]"
+				continue
+			}
+			out[k] = redactValue(child, keys)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item, keys)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,187 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSinkSendAppendsJSONLAndCreatesParentDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "errors.log")
+	sink := FileSink{Path: path}
+
+	entry := Entry{Time: time.Now(), Hook: "PostToolUse", Error: "boom"}
+	if err := sink.Send(context.Background(), entry); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := sink.Send(context.Background(), Entry{Time: time.Now(), Hook: "PreToolUse", Error: "bang"}); err != nil {
+		t.Fatalf("second Send failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), data)
+	}
+
+	var first Entry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("failed to unmarshal first line: %v", err)
+	}
+	if first.Hook != "PostToolUse" || first.Error != "boom" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+}
+
+func TestHTTPSinkSendPostsEntryJSON(t *testing.T) {
+	var received Entry
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := HTTPSink{URL: server.URL}
+	entry := Entry{Time: time.Now(), Hook: "Notification", Error: "webhook failed"}
+	if err := sink.Send(context.Background(), entry); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if contentType != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", contentType)
+	}
+	if received.Hook != "Notification" || received.Error != "webhook failed" {
+		t.Errorf("unexpected entry received: %+v", received)
+	}
+}
+
+func TestHTTPSinkSendReturnsErrorOnNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := HTTPSink{URL: server.URL}
+	if err := sink.Send(context.Background(), Entry{Time: time.Now()}); err == nil {
+		t.Fatal("expected an error for a 500 response")
+	}
+}
+
+func TestSentryEnvelopeEndpointDerivesIngestURL(t *testing.T) {
+	endpoint, err := sentryEnvelopeEndpoint("https://examplekey@o123.ingest.sentry.io/456")
+	if err != nil {
+		t.Fatalf("sentryEnvelopeEndpoint failed: %v", err)
+	}
+	want := "https://o123.ingest.sentry.io/api/456/envelope/"
+	if endpoint != want {
+		t.Errorf("expected %q, got %q", want, endpoint)
+	}
+}
+
+func TestSentryEnvelopeEndpointRejectsDSNWithoutProject(t *testing.T) {
+	if _, err := sentryEnvelopeEndpoint("https://examplekey@o123.ingest.sentry.io/"); err == nil {
+		t.Fatal("expected an error for a DSN with no project ID")
+	}
+}
+
+func TestSentryEnvelopeShapesHeaderAndEventLines(t *testing.T) {
+	entry := Entry{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Hook:    "PostToolUse",
+		Matcher: "Edit",
+		Tool:    "Edit",
+		Error:   "boom",
+		Stack:   "stack trace",
+	}
+	data, err := sentryEnvelope(entry, "v1.2.3", "production")
+	if err != nil {
+		t.Fatalf("sentryEnvelope failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines (header, item header, event), got %d", len(lines))
+	}
+
+	var header map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("failed to unmarshal header: %v", err)
+	}
+	if header["sent_at"] != "2026-01-02T03:04:05Z" {
+		t.Errorf("expected sent_at '2026-01-02T03:04:05Z', got %v", header["sent_at"])
+	}
+
+	var itemHeader map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &itemHeader); err != nil {
+		t.Fatalf("failed to unmarshal item header: %v", err)
+	}
+	if itemHeader["type"] != "event" {
+		t.Errorf("expected item header type 'event', got %v", itemHeader["type"])
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+	if event["release"] != "v1.2.3" || event["environment"] != "production" {
+		t.Errorf("expected release/environment to be tagged, got %v", event)
+	}
+	tags, ok := event["tags"].(map[string]interface{})
+	if !ok || tags["hook"] != "PostToolUse" || tags["tool"] != "Edit" {
+		t.Errorf("expected hook/tool tags, got %v", event["tags"])
+	}
+	if header["event_id"] != event["event_id"] {
+		t.Errorf("expected header and event to share an event_id, got %v vs %v", header["event_id"], event["event_id"])
+	}
+}
+
+func TestRedactReplacesMatchedKeysAtAnyDepth(t *testing.T) {
+	payload := json.RawMessage(`{"token":"secret","nested":{"apiKey":"shh","ok":"visible"},"list":[{"token":"also-secret"}]}`)
+
+	redacted := redact(payload, []string{"token", "apiKey"})
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(redacted, &doc); err != nil {
+		t.Fatalf("failed to unmarshal redacted payload: %v", err)
+	}
+	if doc["token"] != "[redacted]" {
+		t.Errorf("expected top-level token redacted, got %v", doc["token"])
+	}
+	nested := doc["nested"].(map[string]interface{})
+	if nested["apiKey"] != "[redacted]" {
+		t.Errorf("expected nested apiKey redacted, got %v", nested["apiKey"])
+	}
+	if nested["ok"] != "visible" {
+		t.Errorf("expected unmatched key to survive, got %v", nested["ok"])
+	}
+	list := doc["list"].([]interface{})
+	item := list[0].(map[string]interface{})
+	if item["token"] != "[redacted]" {
+		t.Errorf("expected token redacted inside a list element, got %v", item["token"])
+	}
+}
+
+func TestRedactReturnsPayloadUnchangedWhenNoKeysConfigured(t *testing.T) {
+	payload := json.RawMessage(`{"token":"secret"}`)
+	if got := redact(payload, nil); string(got) != string(payload) {
+		t.Errorf("expected payload unchanged, got %s", got)
+	}
+}
+
+func TestRedactReturnsEmptyPayloadUnchanged(t *testing.T) {
+	if got := redact(nil, []string{"token"}); got != nil {
+		t.Errorf("expected nil payload to stay nil, got %q", got)
+	}
+}
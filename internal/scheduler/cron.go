@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronExpr is a parsed standard 5-field cron expression (minute hour
+// day-of-month month day-of-week), evaluated in local time.
+type cronExpr struct {
+	minute, hour, dom, month, dow fieldSet
+}
+
+// fieldSet is the set of values a cron field matches, e.g. {0, 15, 30, 45}
+// for "*/15".
+type fieldSet map[int]bool
+
+// parseCron parses a standard 5-field cron expression.
+func parseCron(expr string) (*cronExpr, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	return &cronExpr{minute: minute, hour: hour, dom: dom, month: month, dow: dow}, nil
+}
+
+// parseField parses a single cron field ("*", "*/N", "A,B,C", or "A-B") into
+// the set of values in [min, max] it matches.
+func parseField(field string, min, max int) (fieldSet, error) {
+	set := fieldSet{}
+
+	for _, part := range strings.Split(field, ",") {
+		if part == "*" {
+			for v := min; v <= max; v++ {
+				set[v] = true
+			}
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(part, "*/"); ok {
+			step, err := strconv.Atoi(rest)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step value %q", part)
+			}
+			for v := min; v <= max; v += step {
+				set[v] = true
+			}
+			continue
+		}
+
+		if lo, hi, ok := strings.Cut(part, "-"); ok {
+			loVal, err1 := strconv.Atoi(lo)
+			hiVal, err2 := strconv.Atoi(hi)
+			if err1 != nil || err2 != nil || loVal > hiVal || loVal < min || hiVal > max {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+			for v := loVal; v <= hiVal; v++ {
+				set[v] = true
+			}
+			continue
+		}
+
+		v, err := strconv.Atoi(part)
+		if err != nil || v < min || v > max {
+			return nil, fmt.Errorf("invalid value %q (must be in [%d, %d])", part, min, max)
+		}
+		set[v] = true
+	}
+
+	return set, nil
+}
+
+// matches reports whether t satisfies the expression, at minute precision.
+func (c *cronExpr) matches(t time.Time) bool {
+	return c.minute[t.Minute()] &&
+		c.hour[t.Hour()] &&
+		c.dom[t.Day()] &&
+		c.month[int(t.Month())] &&
+		c.dow[int(t.Weekday())]
+}
+
+// Due reports whether expr has a matching minute in (since, now] — i.e.
+// whether a schedule last run at since is due to run again by now. since
+// being zero means the schedule has never run, so it's immediately due.
+func Due(expr string, since, now time.Time) (bool, error) {
+	c, err := parseCron(expr)
+	if err != nil {
+		return false, err
+	}
+
+	if since.IsZero() {
+		return true, nil
+	}
+
+	since = since.Truncate(time.Minute)
+	now = now.Truncate(time.Minute)
+	for t := since.Add(time.Minute); !t.After(now); t = t.Add(time.Minute) {
+		if c.matches(t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
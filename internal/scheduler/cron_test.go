@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronRejectsWrongFieldCount(t *testing.T) {
+	if _, err := parseCron("* * *"); err == nil {
+		t.Fatal("expected an error for a 3-field expression")
+	}
+}
+
+func TestParseFieldHandlesWildcardStepRangeAndList(t *testing.T) {
+	cases := []struct {
+		field string
+		min   int
+		max   int
+		want  []int
+	}{
+		{"*/15", 0, 59, []int{0, 15, 30, 45}},
+		{"1-3", 0, 6, []int{1, 2, 3}},
+		{"1,3,5", 0, 6, []int{1, 3, 5}},
+	}
+
+	for _, tc := range cases {
+		set, err := parseField(tc.field, tc.min, tc.max)
+		if err != nil {
+			t.Fatalf("parseField(%q) failed: %v", tc.field, err)
+		}
+		for _, v := range tc.want {
+			if !set[v] {
+				t.Errorf("parseField(%q): expected %d to match", tc.field, v)
+			}
+		}
+		if len(set) != len(tc.want) {
+			t.Errorf("parseField(%q): expected %d matching values, got %d (%v)", tc.field, len(tc.want), len(set), set)
+		}
+	}
+}
+
+func TestParseFieldRejectsOutOfRangeAndMalformedValues(t *testing.T) {
+	for _, field := range []string{"60", "*/0", "5-2", "abc"} {
+		if _, err := parseField(field, 0, 59); err == nil {
+			t.Errorf("parseField(%q) expected an error", field)
+		}
+	}
+}
+
+func TestCronExprMatchesEveryField(t *testing.T) {
+	c, err := parseCron("30 14 1 6 *")
+	if err != nil {
+		t.Fatalf("parseCron failed: %v", err)
+	}
+
+	match := time.Date(2026, time.June, 1, 14, 30, 0, 0, time.UTC)
+	if !c.matches(match) {
+		t.Errorf("expected %v to match", match)
+	}
+
+	noMatch := time.Date(2026, time.June, 1, 14, 31, 0, 0, time.UTC)
+	if c.matches(noMatch) {
+		t.Errorf("expected %v not to match", noMatch)
+	}
+}
+
+func TestDueWithZeroSinceIsImmediatelyDue(t *testing.T) {
+	due, err := Due("*/15 * * * *", time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("Due failed: %v", err)
+	}
+	if !due {
+		t.Error("expected a schedule that has never run to be immediately due")
+	}
+}
+
+func TestDueFindsAMatchingMinuteSinceLastRun(t *testing.T) {
+	since := time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC)
+	now := since.Add(20 * time.Minute)
+
+	due, err := Due("*/15 * * * *", since, now)
+	if err != nil {
+		t.Fatalf("Due failed: %v", err)
+	}
+	if !due {
+		t.Error("expected a */15 schedule to be due 20 minutes after its last run")
+	}
+}
+
+func TestDueReturnsFalseWhenNoMinuteMatchesSinceLastRun(t *testing.T) {
+	since := time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC)
+	now := since.Add(5 * time.Minute)
+
+	due, err := Due("*/15 * * * *", since, now)
+	if err != nil {
+		t.Fatalf("Due failed: %v", err)
+	}
+	if due {
+		t.Error("expected a */15 schedule not to be due 5 minutes after its last run")
+	}
+}
+
+func TestDuePropagatesParseError(t *testing.T) {
+	if _, err := Due("not a cron expression", time.Time{}, time.Now()); err == nil {
+		t.Fatal("expected an error for an invalid cron expression")
+	}
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// runLockPath returns the path to the per-schedule run lock used to guard
+// against a slow hook invocation stacking up with the next poll.
+func runLockPath(id string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentctl", "run-locks", id+".lock"), nil
+}
+
+// tryAcquireRunLock atomically creates schedule id's run lock, reclaiming it
+// first if the recorded holder process is no longer running. It returns
+// (nil, false) without error if another run currently holds the lock.
+func tryAcquireRunLock(id string) (*os.File, bool, error) {
+	path, err := runLockPath(id)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve run lock path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // Lock directory needs to be readable
+		return nil, false, fmt.Errorf("failed to create run lock directory: %w", err)
+	}
+
+	reclaimStaleRunLock(path)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // Lockfile needs to be readable
+	if os.IsExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create run lock: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(file, "%d\n", os.Getpid()); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, false, fmt.Errorf("failed to record run lock holder: %w", err)
+	}
+
+	return file, true, nil
+}
+
+// releaseRunLock closes and removes schedule id's run lock.
+func releaseRunLock(id string, file *os.File) {
+	_ = file.Close()
+	if path, err := runLockPath(id); err == nil {
+		_ = os.Remove(path)
+	}
+}
+
+// reclaimStaleRunLock removes id's run lock if the PID recorded in it no
+// longer refers to a running process. Errors are swallowed: this is a
+// best-effort cleanup step, and a genuinely held lock simply won't be
+// removed.
+func reclaimStaleRunLock(path string) {
+	data, err := os.ReadFile(path) //nolint:gosec // Lock path is derived from the schedule ID, not user input
+	if err != nil {
+		return
+	}
+
+	var pid int
+	if _, err := fmt.Sscanf(string(data), "%d", &pid); err != nil || pid <= 0 {
+		return
+	}
+
+	if processAlive(pid) {
+		return
+	}
+	_ = os.Remove(path)
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestTryAcquireRunLockBlocksConcurrentHolder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	file, acquired, err := tryAcquireRunLock("ci-poll")
+	if err != nil {
+		t.Fatalf("tryAcquireRunLock failed: %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected the first acquisition to succeed")
+	}
+	defer releaseRunLock("ci-poll", file)
+
+	_, acquiredAgain, err := tryAcquireRunLock("ci-poll")
+	if err != nil {
+		t.Fatalf("tryAcquireRunLock failed: %v", err)
+	}
+	if acquiredAgain {
+		t.Error("expected a second acquisition to be refused while the lock is held")
+	}
+}
+
+func TestReleaseRunLockAllowsReacquisition(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	file, acquired, err := tryAcquireRunLock("ci-poll")
+	if err != nil || !acquired {
+		t.Fatalf("expected first acquisition to succeed, got acquired=%v err=%v", acquired, err)
+	}
+	releaseRunLock("ci-poll", file)
+
+	_, acquiredAgain, err := tryAcquireRunLock("ci-poll")
+	if err != nil {
+		t.Fatalf("tryAcquireRunLock failed: %v", err)
+	}
+	if !acquiredAgain {
+		t.Error("expected reacquisition to succeed after release")
+	}
+	defer releaseRunLock("ci-poll", nil)
+}
+
+func TestTryAcquireRunLockReclaimsStaleLock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := runLockPath("ci-poll")
+	if err != nil {
+		t.Fatalf("runLockPath failed: %v", err)
+	}
+	if err := os.MkdirAll(path[:len(path)-len("/ci-poll.lock")], 0755); err != nil {
+		t.Fatalf("failed to create lock dir: %v", err)
+	}
+	// A PID that's very unlikely to be running: os.Getpid() is already in
+	// use by this test process, so add a large offset instead of guessing
+	// an arbitrary fixed PID.
+	stalePID := os.Getpid() + 1_000_000
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n", stalePID)), 0644); err != nil {
+		t.Fatalf("failed to write stale lock: %v", err)
+	}
+
+	file, acquired, err := tryAcquireRunLock("ci-poll")
+	if err != nil {
+		t.Fatalf("tryAcquireRunLock failed: %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected a lock held by a dead PID to be reclaimed")
+	}
+	releaseRunLock("ci-poll", file)
+}
+
+func TestProcessAliveReportsCurrentProcessAsAlive(t *testing.T) {
+	if !processAlive(os.Getpid()) {
+		t.Error("expected the current process to report as alive")
+	}
+}
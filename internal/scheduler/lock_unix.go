@@ -0,0 +1,19 @@
+//go:build !windows
+
+package scheduler
+
+import (
+	"os"
+	"syscall"
+)
+
+// processAlive reports whether pid refers to a currently running process.
+// On Unix, os.FindProcess always succeeds, so liveness is checked by sending
+// signal 0, which performs permission/existence checks without side effects.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
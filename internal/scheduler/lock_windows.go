@@ -0,0 +1,16 @@
+//go:build windows
+
+package scheduler
+
+import "syscall"
+
+// processAlive reports whether pid refers to a currently running process by
+// attempting to open it; OpenProcess fails once the process has exited.
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+	return true
+}
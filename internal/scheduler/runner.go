@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// RunDue runs every schedule in store whose cron trigger has a matching
+// minute since its last recorded run, at minute-precision relative to now.
+// It returns the IDs of schedules it ran (whether or not the hook itself
+// succeeded) and the first error encountered resolving/running a schedule,
+// if any; a single misbehaving schedule doesn't stop the others from being
+// considered.
+func RunDue(ctx context.Context, store *Store, now time.Time) ([]string, error) {
+	state, err := loadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedule run state: %w", err)
+	}
+
+	var ran []string
+	var firstErr error
+
+	for _, sched := range store.Schedules {
+		due, err := Due(sched.Cron, state.LastRun[sched.ID], now)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("schedule %s: %w", sched.ID, err)
+			}
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		ran = append(ran, sched.ID)
+		state.LastRun[sched.ID] = now
+		if err := runOnce(ctx, sched); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("schedule %s: %w", sched.ID, err)
+		}
+	}
+
+	if err := saveState(state); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("failed to save schedule run state: %w", err)
+	}
+
+	return ran, firstErr
+}
+
+// runOnce invokes schedule's hook, guarded by its per-schedule run lock so a
+// slow previous invocation can't stack up with the next poll.
+func runOnce(ctx context.Context, sched Schedule) error {
+	file, acquired, err := tryAcquireRunLock(sched.ID)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return fmt.Errorf("previous run of schedule %s is still in progress, skipping", sched.ID)
+	}
+	defer releaseRunLock(sched.ID, file)
+
+	if sched.WorkspaceFilter == "" {
+		return invokeHook(ctx, sched.Hook, sched.Args)
+	}
+
+	workspaces, err := matchingWorkspaces(ctx, sched.WorkspaceFilter)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, ws := range workspaces {
+		args := append([]string{"--workspace", ws}, sched.Args...)
+		if err := invokeHook(ctx, sched.Hook, args); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// invokeHook runs `agentctl hook <name> <args...>`, mirroring the
+// self-invocation convention internal/hook/context.go already uses to
+// reach workspace/PR state from within a hook.
+func invokeHook(ctx context.Context, name string, args []string) error {
+	cmdArgs := append([]string{"hook", name}, args...)
+	cmd := exec.CommandContext(ctx, "agentctl", cmdArgs...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("agentctl hook %s failed: %w (%s)", name, err, string(out))
+	}
+	return nil
+}
+
+// matchingWorkspaces runs `agentctl workspace list --json` (mirroring
+// internal/hook/context.go's self-invocation convention for learning
+// workspace state) and returns the branch names matching filter: "all", or
+// a specific status value like "clean"/"dirty".
+func matchingWorkspaces(ctx context.Context, filter string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "agentctl", "workspace", "list", "--json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var workspaces []map[string]interface{}
+	if err := json.Unmarshal(output, &workspaces); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace list: %w", err)
+	}
+
+	var branches []string
+	for _, ws := range workspaces {
+		branch, _ := ws["branch"].(string)
+		if branch == "" {
+			continue
+		}
+		if filter == "all" {
+			branches = append(branches, branch)
+			continue
+		}
+		if isClean, _ := ws["is_clean"].(bool); filter == "clean" && isClean {
+			branches = append(branches, branch)
+		} else if filter == "dirty" && !isClean {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
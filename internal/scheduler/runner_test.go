@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// stubAgentctl puts a fake `agentctl` script on PATH that records each
+// invocation's args to logPath, one JSON-free line per call, so runOnce's
+// self-invocation (invokeHook/matchingWorkspaces) can be exercised without a
+// real binary.
+func stubAgentctl(t *testing.T, logPath, workspaceListJSON string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub shell script relies on a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+echo "$@" >> %q
+if [ "$1" = "workspace" ] && [ "$2" = "list" ]; then
+  cat <<'EOF'
+%s
+EOF
+fi
+`, logPath, workspaceListJSON)
+
+	path := filepath.Join(dir, "agentctl")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write stub agentctl: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRunOnceInvokesHookDirectlyWithoutWorkspaceFilter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	stubAgentctl(t, logPath, "[]")
+
+	err := runOnce(context.Background(), Schedule{ID: "ci-poll", Hook: "context-info"})
+	if err != nil {
+		t.Fatalf("runOnce failed: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read call log: %v", err)
+	}
+	if got := string(data); got != "hook context-info\n" {
+		t.Errorf("expected a single 'hook context-info' call, got %q", got)
+	}
+}
+
+func TestRunOnceFansOutAcrossMatchingWorkspaces(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	stubAgentctl(t, logPath, `[{"branch":"feature-a","is_clean":true},{"branch":"feature-b","is_clean":false}]`)
+
+	err := runOnce(context.Background(), Schedule{ID: "review-check", Hook: "notify-stop", WorkspaceFilter: "clean"})
+	if err != nil {
+		t.Fatalf("runOnce failed: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read call log: %v", err)
+	}
+	want := "workspace list --json\nhook notify-stop --workspace feature-a\n"
+	if string(data) != want {
+		t.Errorf("expected calls %q, got %q", want, data)
+	}
+}
+
+func TestRunOnceRefusesConcurrentRunOfSameSchedule(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	stubAgentctl(t, logPath, "[]")
+
+	file, acquired, err := tryAcquireRunLock("ci-poll")
+	if err != nil || !acquired {
+		t.Fatalf("expected to acquire the lock up front, got acquired=%v err=%v", acquired, err)
+	}
+	defer releaseRunLock("ci-poll", file)
+
+	err = runOnce(context.Background(), Schedule{ID: "ci-poll", Hook: "context-info"})
+	if err == nil {
+		t.Fatal("expected runOnce to refuse to run while the schedule's lock is held")
+	}
+}
+
+func TestRunDueRunsOnlyDueSchedulesAndRecordsLastRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	stubAgentctl(t, logPath, "[]")
+
+	now := time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC)
+	store := &Store{Schedules: []Schedule{
+		{ID: "every-quarter-hour", Cron: "*/15 * * * *", Hook: "context-info"},
+		{ID: "never-this-minute", Cron: "1 0 1 1 *", Hook: "context-info"},
+	}}
+
+	// Both schedules need a recorded last run, otherwise Due treats a
+	// never-run schedule as immediately due regardless of its cron
+	// expression, which would defeat this test.
+	if err := saveState(&State{LastRun: map[string]time.Time{
+		"every-quarter-hour": now.Add(-20 * time.Minute),
+		"never-this-minute":  now.Add(-20 * time.Minute),
+	}}); err != nil {
+		t.Fatalf("saveState failed: %v", err)
+	}
+
+	ran, err := RunDue(context.Background(), store, now)
+	if err != nil {
+		t.Fatalf("RunDue failed: %v", err)
+	}
+	if len(ran) != 1 || ran[0] != "every-quarter-hour" {
+		t.Fatalf("expected only 'every-quarter-hour' to run, got %v", ran)
+	}
+
+	state, err := loadState()
+	if err != nil {
+		t.Fatalf("loadState failed: %v", err)
+	}
+	if !state.LastRun["every-quarter-hour"].Equal(now) {
+		t.Errorf("expected last run to be recorded as %v, got %v", now, state.LastRun["every-quarter-hour"])
+	}
+	if want := now.Add(-20 * time.Minute); !state.LastRun["never-this-minute"].Equal(want) {
+		t.Errorf("expected a schedule that didn't run to keep its prior last-run time %v, got %v", want, state.LastRun["never-this-minute"])
+	}
+}
@@ -0,0 +1,97 @@
+// Package scheduler runs registered agentctl hooks on cron-style triggers,
+// persisting the schedule list and per-schedule run locks under
+// ~/.config/agentctl/.
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schedule is one entry in schedules.yaml: a hook to run on a cron trigger.
+type Schedule struct {
+	ID              string   `yaml:"id" json:"id"`
+	Cron            string   `yaml:"cron" json:"cron"`
+	Hook            string   `yaml:"hook" json:"hook"`
+	Args            []string `yaml:"args,omitempty" json:"args,omitempty"`
+	WorkspaceFilter string   `yaml:"workspace_filter,omitempty" json:"workspace_filter,omitempty"`
+}
+
+// Store is the `~/.config/agentctl/schedules.yaml` schema.
+type Store struct {
+	Schedules []Schedule `yaml:"schedules"`
+}
+
+// Path returns the path to the schedule store.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentctl", "schedules.yaml"), nil
+}
+
+// Load reads the schedule store, returning an empty Store if none exists
+// yet.
+func Load() (*Store, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the user's home directory
+	if os.IsNotExist(err) {
+		return &Store{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var store Store
+	if err := yaml.Unmarshal(data, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// Save writes store to the schedule store.
+func Save(store *Store) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(store)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644) //nolint:gosec // Store needs to be readable
+}
+
+// Find returns the schedule with the given ID, if any.
+func (s *Store) Find(id string) (*Schedule, bool) {
+	for i := range s.Schedules {
+		if s.Schedules[i].ID == id {
+			return &s.Schedules[i], true
+		}
+	}
+	return nil, false
+}
+
+// Remove deletes the schedule with the given ID, reporting whether one was
+// found.
+func (s *Store) Remove(id string) bool {
+	for i, sched := range s.Schedules {
+		if sched.ID == id {
+			s.Schedules = append(s.Schedules[:i], s.Schedules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
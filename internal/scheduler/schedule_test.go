@@ -0,0 +1,69 @@
+package scheduler
+
+import "testing"
+
+func TestLoadReturnsEmptyStoreWhenNoneExists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(store.Schedules) != 0 {
+		t.Errorf("expected an empty store, got %v", store.Schedules)
+	}
+}
+
+func TestSaveThenLoadRoundTrips(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store := &Store{Schedules: []Schedule{
+		{ID: "ci-poll", Cron: "*/15 * * * *", Hook: "context-info"},
+		{ID: "review-check", Cron: "0 * * * *", Hook: "notify-stop", Args: []string{"--quiet"}, WorkspaceFilter: "clean"},
+	}}
+	if err := Save(store); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Schedules) != 2 {
+		t.Fatalf("expected 2 schedules, got %d", len(loaded.Schedules))
+	}
+	if loaded.Schedules[1].WorkspaceFilter != "clean" || loaded.Schedules[1].Args[0] != "--quiet" {
+		t.Errorf("unexpected round-tripped schedule: %+v", loaded.Schedules[1])
+	}
+}
+
+func TestStoreFindReturnsScheduleByID(t *testing.T) {
+	store := &Store{Schedules: []Schedule{{ID: "a"}, {ID: "b"}}}
+
+	sched, ok := store.Find("b")
+	if !ok || sched.ID != "b" {
+		t.Fatalf("expected to find schedule 'b', got %+v, %v", sched, ok)
+	}
+
+	if _, ok := store.Find("missing"); ok {
+		t.Error("expected no schedule for an unknown ID")
+	}
+}
+
+func TestStoreRemoveDeletesScheduleByID(t *testing.T) {
+	store := &Store{Schedules: []Schedule{{ID: "a"}, {ID: "b"}, {ID: "c"}}}
+
+	if !store.Remove("b") {
+		t.Fatal("expected Remove to report removing 'b'")
+	}
+	if len(store.Schedules) != 2 {
+		t.Fatalf("expected 2 remaining schedules, got %d", len(store.Schedules))
+	}
+	if _, ok := store.Find("b"); ok {
+		t.Error("expected 'b' to be gone")
+	}
+
+	if store.Remove("missing") {
+		t.Error("expected Remove to report false for an unknown ID")
+	}
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the `~/.config/agentctl/schedule_state.json` schema: the last
+// time each schedule ran, so Due can tell whether a cron tick was missed
+// between runner polls.
+type State struct {
+	LastRun map[string]time.Time `json:"last_run"`
+}
+
+// statePath returns the path to the schedule run-state file.
+func statePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "agentctl", "schedule_state.json"), nil
+}
+
+// loadState reads the run-state file, returning an empty State if none
+// exists yet (e.g. no schedule has ever run).
+func loadState() (*State, error) {
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the user's home directory
+	if os.IsNotExist(err) {
+		return &State{LastRun: map[string]time.Time{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if state.LastRun == nil {
+		state.LastRun = map[string]time.Time{}
+	}
+	return &state, nil
+}
+
+// saveState writes state to the run-state file.
+func saveState(state *State) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644) //nolint:gosec // State needs to be readable
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadStateReturnsEmptyStateWhenNoneExists(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	state, err := loadState()
+	if err != nil {
+		t.Fatalf("loadState failed: %v", err)
+	}
+	if state.LastRun == nil || len(state.LastRun) != 0 {
+		t.Errorf("expected an empty, non-nil LastRun map, got %v", state.LastRun)
+	}
+}
+
+func TestSaveStateThenLoadStateRoundTrips(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	run := time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC)
+	state := &State{LastRun: map[string]time.Time{"ci-poll": run}}
+	if err := saveState(state); err != nil {
+		t.Fatalf("saveState failed: %v", err)
+	}
+
+	loaded, err := loadState()
+	if err != nil {
+		t.Fatalf("loadState failed: %v", err)
+	}
+	if !loaded.LastRun["ci-poll"].Equal(run) {
+		t.Errorf("expected ci-poll's last run to round-trip as %v, got %v", run, loaded.LastRun["ci-poll"])
+	}
+}
@@ -2,6 +2,9 @@ package setup
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,25 +13,66 @@ import (
 	"time"
 
 	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/manifest"
 	"github.com/ryantking/agentctl/internal/templates"
+	"github.com/ryantking/agentctl/internal/updater"
 )
 
 // Manager manages Claude Code initialization.
 type Manager struct {
-	target      string
-	templateDir string
+	target           string
+	templateDir      string
+	manifest         *manifest.Manifest
+	toolVersion      string
+	settingOverrides map[string]interface{}
 }
 
-// NewManager creates a new initialization manager.
+// SetToolVersion records the agentctl version written to the lockfile
+// during Install. Defaults to empty when not set.
+func (m *Manager) SetToolVersion(version string) {
+	m.toolVersion = version
+}
+
+// SetSettingOverrides records dotted-path settings overrides (e.g.
+// "hooks.PostToolUse.0.command") applied on top of the manifest's
+// SettingsOverrides during Install, for a caller-supplied override such as
+// the init command's --set flag. Applied via config.ApplyOverrides, so
+// ordinary strategic merge directives still work within override values.
+func (m *Manager) SetSettingOverrides(overrides map[string]interface{}) {
+	m.settingOverrides = overrides
+}
+
+// NewManager creates a new initialization manager using the embedded
+// default manifest.
 func NewManager(target string) (*Manager, error) {
+	return NewManagerWithManifest(target, "")
+}
+
+// NewManagerWithManifest creates a new initialization manager that installs
+// from manifestPath's agentctl.yaml instead of the built-in defaults. An
+// empty manifestPath falls back to the embedded default manifest, so
+// behavior is unchanged unless a user supplies their own.
+func NewManagerWithManifest(target, manifestPath string) (*Manager, error) {
+	var mf *manifest.Manifest
+	var err error
+	if manifestPath != "" {
+		mf, err = manifest.Load(manifestPath)
+	} else {
+		mf, err = manifest.LoadDefault()
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return &Manager{
 		target:      target,
 		templateDir: "templates", // Embedded templates path
+		manifest:    mf,
 	}, nil
 }
 
 // Install executes full initialization.
-func (m *Manager) Install(force, skipIndex bool) error {
+func (m *Manager) Install(ctx context.Context, force, skipIndex bool) error {
 	// 1. Install CLAUDE.md
 	fmt.Println("Installing CLAUDE.md...")
 	if err := m.installFile("CLAUDE.md", filepath.Join(m.target, "CLAUDE.md"), force); err != nil {
@@ -36,20 +80,28 @@ func (m *Manager) Install(force, skipIndex bool) error {
 	}
 
 	// 2. Install agents
-	fmt.Println("Installing agents...")
-	count, err := m.installDirectory("agents", filepath.Join(m.target, ".claude", "agents"), force, false, "*.md")
-	if err != nil {
-		return err
+	if len(m.manifest.EnabledAgents()) == 0 {
+		fmt.Println("Installing agents... (none enabled in manifest, skipped)")
+	} else {
+		fmt.Println("Installing agents...")
+		count, err := m.installDirectory("agents", filepath.Join(m.target, ".claude", "agents"), force, false, "*.md")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  → Installed %d agent(s)\n", count)
 	}
-	fmt.Printf("  → Installed %d agent(s)\n", count)
 
 	// 3. Install skills
-	fmt.Println("Installing skills...")
-	count, err = m.installDirectory("skills", filepath.Join(m.target, ".claude", "skills"), force, true, "")
-	if err != nil {
-		return err
+	if len(m.manifest.EnabledSkills()) == 0 {
+		fmt.Println("Installing skills... (none enabled in manifest, skipped)")
+	} else {
+		fmt.Println("Installing skills...")
+		count, err := m.installDirectory("skills", filepath.Join(m.target, ".claude", "skills"), force, true, "")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  → Installed %d skill(s)\n", count)
 	}
-	fmt.Printf("  → Installed %d skill(s)\n", count)
 
 	// 4. Merge settings
 	fmt.Println("Merging settings.json...")
@@ -63,9 +115,15 @@ func (m *Manager) Install(force, skipIndex bool) error {
 		return err
 	}
 
-	// 6. Index repository with claude CLI
+	// 6. Write lockfile for `agentctl update`
+	fmt.Println("Writing lockfile...")
+	if err := m.writeLockfile(); err != nil {
+		return err
+	}
+
+	// 7. Index repository with claude CLI
 	if !skipIndex {
-		if err := m.indexRepository(); err != nil {
+		if err := m.indexRepository(ctx); err != nil {
 			// Non-fatal error
 			fmt.Printf("  → Repository indexing skipped: %v\n", err)
 		}
@@ -228,6 +286,12 @@ func (m *Manager) mergeSettings(force bool) error {
 
 	if _, err := os.Stat(destPath); os.IsNotExist(err) {
 		// No existing settings - just copy
+		if len(m.manifest.SettingsOverrides) > 0 {
+			newSettings = config.Merge(newSettings, m.manifest.SettingsOverrides)
+		}
+		if len(m.settingOverrides) > 0 {
+			newSettings = config.ApplyOverrides(newSettings, m.settingOverrides)
+		}
 		data, err := config.SaveJSON(newSettings)
 		if err != nil {
 			return err
@@ -267,6 +331,12 @@ func (m *Manager) mergeSettings(force bool) error {
 
 	// Smart merge
 	merged := config.Merge(existingSettings, newSettings)
+	if len(m.manifest.SettingsOverrides) > 0 {
+		merged = config.Merge(merged, m.manifest.SettingsOverrides)
+	}
+	if len(m.settingOverrides) > 0 {
+		merged = config.ApplyOverrides(merged, m.settingOverrides)
+	}
 	data, err := config.SaveJSON(merged)
 	if err != nil {
 		return err
@@ -282,16 +352,10 @@ func (m *Manager) mergeSettings(force bool) error {
 func (m *Manager) configureMCP(force bool) error {
 	destPath := filepath.Join(m.target, ".mcp.json")
 
-	// New MCP servers to add
-	newServers := map[string]interface{}{
-		"context7": map[string]interface{}{
-			"type": "http",
-			"url":  "https://mcp.context7.com/mcp",
-		},
-		"linear": map[string]interface{}{
-			"type": "sse",
-			"url":  "https://mcp.linear.app/sse",
-		},
+	// New MCP servers to add, from the manifest
+	newServers := make(map[string]interface{}, len(m.manifest.MCPServers))
+	for name, server := range m.manifest.MCPServers {
+		newServers[name] = server.ToJSON()
 	}
 
 	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
@@ -363,7 +427,128 @@ func (m *Manager) configureMCP(force bool) error {
 	return nil
 }
 
-func (m *Manager) indexRepository() error {
+// writeLockfile records the SHA-256 and source of every agent, skill,
+// settings block, and MCP server entry Install wrote, so `agentctl update`
+// can later tell upstream template drift apart from local edits.
+func (m *Manager) writeLockfile() error {
+	var entries []updater.LockEntry
+
+	agentFiles, err := templates.FS.ReadDir("templates/agents")
+	if err == nil {
+		for _, e := range agentFiles {
+			if e.IsDir() || !matchPattern(e.Name(), "*.md") {
+				continue
+			}
+			entry, err := m.buildLockEntry(
+				filepath.Join(m.target, ".claude", "agents", e.Name()),
+				"agent",
+				filepath.Join("templates", "agents", e.Name()),
+			)
+			if err == nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	skillDirs, err := templates.FS.ReadDir("templates/skills")
+	if err == nil {
+		for _, e := range skillDirs {
+			if !e.IsDir() {
+				continue
+			}
+			sub, err := m.lockEntriesForTree(
+				filepath.Join("templates", "skills", e.Name()),
+				filepath.Join(m.target, ".claude", "skills", e.Name()),
+				"skill",
+			)
+			if err == nil {
+				entries = append(entries, sub...)
+			}
+		}
+	}
+
+	if entry, err := m.buildLockEntry(
+		filepath.Join(m.target, ".claude", "settings.json"), "settings", "settings.json",
+	); err == nil {
+		entries = append(entries, entry)
+	}
+
+	for name, server := range m.manifest.MCPServers {
+		data, err := json.Marshal(server.ToJSON())
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		entries = append(entries, updater.LockEntry{
+			Path:   ".mcp.json#" + name,
+			Kind:   "mcp_server",
+			Source: "manifest",
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	return updater.Save(m.target, &updater.Lockfile{
+		ToolVersion: m.toolVersion,
+		Items:       entries,
+	})
+}
+
+// lockEntriesForTree recursively builds lock entries for every file under
+// templateRoot, mirroring copyTree's recursion for skill directories.
+func (m *Manager) lockEntriesForTree(templateRoot, destRoot, kind string) ([]updater.LockEntry, error) {
+	var entries []updater.LockEntry
+
+	dirEntries, err := templates.FS.ReadDir(templateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range dirEntries {
+		templatePath := filepath.Join(templateRoot, e.Name())
+		destPath := filepath.Join(destRoot, e.Name())
+
+		if e.IsDir() {
+			sub, err := m.lockEntriesForTree(templatePath, destPath, kind)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, sub...)
+			continue
+		}
+
+		if entry, err := m.buildLockEntry(destPath, kind, templatePath); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// buildLockEntry hashes the installed file at destPath. It returns an error
+// (and no entry) when the file doesn't exist, e.g. because it was skipped
+// or excluded from the manifest.
+func (m *Manager) buildLockEntry(destPath, kind, templatePath string) (updater.LockEntry, error) {
+	data, err := os.ReadFile(destPath) //nolint:gosec // Path is derived from the install target
+	if err != nil {
+		return updater.LockEntry{}, err
+	}
+
+	relPath, err := filepath.Rel(m.target, destPath)
+	if err != nil {
+		relPath = destPath
+	}
+
+	sum := sha256.Sum256(data)
+	return updater.LockEntry{
+		Path:         relPath,
+		Kind:         kind,
+		Source:       "embedded",
+		TemplatePath: templatePath,
+		SHA256:       hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func (m *Manager) indexRepository(ctx context.Context) error {
 	if _, err := exec.LookPath("claude"); err != nil {
 		return fmt.Errorf("claude CLI not found")
 	}
@@ -379,7 +564,7 @@ Format as clean markdown starting at heading level 3 (###), keep it brief (under
 
 	fmt.Print("  → Indexing repository with Claude CLI...")
 
-	cmdCtx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	cmdCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
 
 	cmd := exec.CommandContext(cmdCtx, "claude", "--print", "--output-format", "text", prompt)
@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// Config controls the notify-slack hook. It's loaded from settings.json's
+// "notifications.slack" block, which config.Merge combines across the
+// user (~/.claude/settings.json) and project (<repoRoot>/.claude/settings.json)
+// scopes like every other agentctl setting.
+type Config struct {
+	WebhookURL string `json:"webhookUrl"`
+	Channel    string `json:"channel,omitempty"`
+	// Mentions maps a session ID or username to the Slack user ID to
+	// @-mention when a notification's session/user matches, e.g.
+	// {"alice": "U0123ABC"}.
+	Mentions map[string]string `json:"mentions,omitempty"`
+}
+
+// LoadConfig reads the "notifications.slack" block from user settings,
+// merged with project settings when ctx resolves to a git repository. Any
+// read or parse failure yields the zero Config (WebhookURL "" means the
+// caller should skip sending), since a misconfigured notifier must never
+// block the hook it's attached to.
+func LoadConfig(ctx context.Context) Config {
+	settings := map[string]interface{}{}
+
+	if data, err := os.ReadFile(userSettingsPath()); err == nil {
+		if parsed, err := config.LoadJSON(data); err == nil {
+			settings = parsed
+		}
+	}
+
+	if repoRoot, err := git.GetRepoRoot(ctx); err == nil {
+		if data, err := os.ReadFile(projectSettingsPath(repoRoot)); err == nil {
+			if parsed, err := config.LoadJSON(data); err == nil {
+				settings = config.Merge(settings, parsed)
+			}
+		}
+	}
+
+	notifications, ok := settings["notifications"].(map[string]interface{})
+	if !ok {
+		return Config{}
+	}
+	raw, ok := notifications["slack"].(map[string]interface{})
+	if !ok {
+		return Config{}
+	}
+
+	data, err := config.SaveJSON(raw)
+	if err != nil {
+		return Config{}
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}
+	}
+	return cfg
+}
+
+// MentionFor returns a Mention element for key (a session ID or username)
+// if cfg.Mentions has an entry for it.
+func (cfg Config) MentionFor(key string) (Element, bool) {
+	userID, ok := cfg.Mentions[key]
+	if !ok || userID == "" {
+		return Element{}, false
+	}
+	return Mention(userID), true
+}
+
+func userSettingsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".claude", "settings.json")
+}
+
+func projectSettingsPath(repoRoot string) string {
+	return filepath.Join(repoRoot, ".claude", "settings.json")
+}
@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+)
+
+func TestMentionForReturnsMentionWhenConfigured(t *testing.T) {
+	cfg := Config{Mentions: map[string]string{"alice": "U0123ABC"}}
+
+	mention, ok := cfg.MentionFor("alice")
+	if !ok {
+		t.Fatal("expected a mention for 'alice'")
+	}
+	if mention != Mention("U0123ABC") {
+		t.Errorf("expected Mention(\"U0123ABC\"), got %v", mention)
+	}
+
+	if _, ok := cfg.MentionFor("bob"); ok {
+		t.Error("expected no mention for an unconfigured key")
+	}
+}
+
+func TestMentionForIgnoresEmptyUserID(t *testing.T) {
+	cfg := Config{Mentions: map[string]string{"alice": ""}}
+
+	if _, ok := cfg.MentionFor("alice"); ok {
+		t.Error("expected no mention when the configured user ID is empty")
+	}
+}
+
+func TestLoadConfigMergesUserAndProjectSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0755); err != nil {
+		t.Fatalf("failed to create user .claude dir: %v", err)
+	}
+	userSettings := `{"notifications":{"slack":{"webhookUrl":"https://hooks.slack.com/user","channel":"#user-channel"}}}`
+	if err := os.WriteFile(filepath.Join(home, ".claude", "settings.json"), []byte(userSettings), 0644); err != nil {
+		t.Fatalf("failed to write user settings: %v", err)
+	}
+
+	repoRoot := t.TempDir()
+	if _, err := gogit.PlainInit(repoRoot, false); err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(repoRoot, ".claude"), 0755); err != nil {
+		t.Fatalf("failed to create project .claude dir: %v", err)
+	}
+	projectSettings := `{"notifications":{"slack":{"channel":"#project-channel","mentions":{"alice":"U0123ABC"}}}}`
+	if err := os.WriteFile(filepath.Join(repoRoot, ".claude", "settings.json"), []byte(projectSettings), 0644); err != nil {
+		t.Fatalf("failed to write project settings: %v", err)
+	}
+
+	t.Chdir(repoRoot)
+
+	cfg := LoadConfig(context.Background())
+
+	if cfg.WebhookURL != "https://hooks.slack.com/user" {
+		t.Errorf("expected webhook URL to come from user settings, got %q", cfg.WebhookURL)
+	}
+	if cfg.Channel != "#project-channel" {
+		t.Errorf("expected channel to be overridden by project settings, got %q", cfg.Channel)
+	}
+	if cfg.Mentions["alice"] != "U0123ABC" {
+		t.Errorf("expected mentions from project settings, got %v", cfg.Mentions)
+	}
+}
+
+func TestLoadConfigReturnsZeroValueWhenUnconfigured(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	cfg := LoadConfig(context.Background())
+
+	if cfg.WebhookURL != "" {
+		t.Errorf("expected an empty Config when nothing is configured, got %v", cfg)
+	}
+}
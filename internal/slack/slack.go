@@ -0,0 +1,128 @@
+// Package slack builds and sends Slack Block Kit messages for the
+// notify-slack hook, using rich_text blocks instead of a plain "text"
+// string so tool names, file links, and command output render with real
+// formatting in the channel.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Element is one inline element within a rich_text_section or
+// rich_text_preformatted block: plain text, a link, an emoji, or a user
+// mention. Only the fields Slack's API expects for each Type are set.
+type Element struct {
+	Type   string        `json:"type"`
+	Text   string        `json:"text,omitempty"`
+	URL    string        `json:"url,omitempty"`
+	Name   string        `json:"name,omitempty"`
+	UserID string        `json:"user_id,omitempty"`
+	Style  *elementStyle `json:"style,omitempty"`
+}
+
+type elementStyle struct {
+	Bold bool `json:"bold,omitempty"`
+	Code bool `json:"code,omitempty"`
+}
+
+// Text returns a plain rich_text "text" element.
+func Text(s string) Element {
+	return Element{Type: "text", Text: s}
+}
+
+// Bold returns a rich_text "text" element rendered bold.
+func Bold(s string) Element {
+	return Element{Type: "text", Text: s, Style: &elementStyle{Bold: true}}
+}
+
+// Link returns a rich_text "link" element.
+func Link(url, text string) Element {
+	return Element{Type: "link", URL: url, Text: text}
+}
+
+// Emoji returns a rich_text "emoji" element by shortcode name (no colons).
+func Emoji(name string) Element {
+	return Element{Type: "emoji", Name: name}
+}
+
+// Mention returns a rich_text "user" element that @-mentions userID.
+func Mention(userID string) Element {
+	return Element{Type: "user", UserID: userID}
+}
+
+// richTextSection is a paragraph of inline elements (rich_text_section) or
+// a monospaced block (rich_text_preformatted) inside a rich_text block.
+type richTextSection struct {
+	Type     string    `json:"type"`
+	Elements []Element `json:"elements"`
+}
+
+// richTextBlock is the top-level Block Kit block carrying one or more
+// sections.
+type richTextBlock struct {
+	Type     string            `json:"type"`
+	Elements []richTextSection `json:"elements"`
+}
+
+// Message is a single Slack incoming-webhook payload built from rich_text
+// blocks rather than a "text" field.
+type Message struct {
+	Channel string          `json:"channel,omitempty"`
+	Blocks  []richTextBlock `json:"blocks"`
+}
+
+// NewMessage starts an empty Message, optionally overriding the webhook's
+// default channel.
+func NewMessage(channel string) *Message {
+	return &Message{Channel: channel, Blocks: []richTextBlock{{Type: "rich_text"}}}
+}
+
+// AddSection appends a rich_text_section paragraph built from elements
+// (Text, Bold, Link, Emoji, Mention) to the message's rich_text block.
+func (m *Message) AddSection(elements ...Element) *Message {
+	m.Blocks[0].Elements = append(m.Blocks[0].Elements, richTextSection{
+		Type:     "rich_text_section",
+		Elements: elements,
+	})
+	return m
+}
+
+// AddPreformatted appends a rich_text_preformatted (monospaced code block)
+// section containing text.
+func (m *Message) AddPreformatted(text string) *Message {
+	m.Blocks[0].Elements = append(m.Blocks[0].Elements, richTextSection{
+		Type:     "rich_text_preformatted",
+		Elements: []Element{Text(text)},
+	})
+	return m
+}
+
+// Send POSTs msg to webhookURL, the format every Slack "Incoming Webhook"
+// integration expects.
+func Send(ctx context.Context, webhookURL string, msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
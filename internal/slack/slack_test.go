@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessageBuildsExpectedBlockKitShape(t *testing.T) {
+	msg := NewMessage("#eng").
+		AddSection(Bold("PostToolUse"), Text(" ran "), Link("https://example.com", "Edit"), Emoji("white_check_mark"), Mention("U123")).
+		AddPreformatted("$ go test ./...")
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded["channel"] != "#eng" {
+		t.Errorf("expected channel '#eng', got %v", decoded["channel"])
+	}
+
+	blocks, ok := decoded["blocks"].([]interface{})
+	if !ok || len(blocks) != 1 {
+		t.Fatalf("expected a single rich_text block, got %v", decoded["blocks"])
+	}
+	block := blocks[0].(map[string]interface{})
+	if block["type"] != "rich_text" {
+		t.Errorf("expected block type 'rich_text', got %v", block["type"])
+	}
+
+	sections, ok := block["elements"].([]interface{})
+	if !ok || len(sections) != 2 {
+		t.Fatalf("expected 2 sections (section + preformatted), got %v", block["elements"])
+	}
+
+	section := sections[0].(map[string]interface{})
+	if section["type"] != "rich_text_section" {
+		t.Errorf("expected first section type 'rich_text_section', got %v", section["type"])
+	}
+	elements := section["elements"].([]interface{})
+	if len(elements) != 5 {
+		t.Fatalf("expected 5 elements in the section, got %d", len(elements))
+	}
+
+	bold := elements[0].(map[string]interface{})
+	if bold["type"] != "text" || bold["text"] != "PostToolUse" {
+		t.Errorf("expected a bold text element, got %v", bold)
+	}
+	style, ok := bold["style"].(map[string]interface{})
+	if !ok || style["bold"] != true {
+		t.Errorf("expected the first element to be styled bold, got %v", bold["style"])
+	}
+
+	link := elements[2].(map[string]interface{})
+	if link["type"] != "link" || link["url"] != "https://example.com" || link["text"] != "Edit" {
+		t.Errorf("expected a link element, got %v", link)
+	}
+
+	emoji := elements[3].(map[string]interface{})
+	if emoji["type"] != "emoji" || emoji["name"] != "white_check_mark" {
+		t.Errorf("expected an emoji element, got %v", emoji)
+	}
+
+	mention := elements[4].(map[string]interface{})
+	if mention["type"] != "user" || mention["user_id"] != "U123" {
+		t.Errorf("expected a user mention element, got %v", mention)
+	}
+
+	preformatted := sections[1].(map[string]interface{})
+	if preformatted["type"] != "rich_text_preformatted" {
+		t.Errorf("expected second section type 'rich_text_preformatted', got %v", preformatted["type"])
+	}
+}
+
+func TestTextElementOmitsStyleWhenPlain(t *testing.T) {
+	data, err := json.Marshal(Text("plain"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, ok := decoded["style"]; ok {
+		t.Errorf("expected a plain Text element to omit 'style', got %v", decoded)
+	}
+}
@@ -2,12 +2,15 @@
 package templates
 
 import (
+	"bytes"
 	"embed"
 	"path/filepath"
+	"text/template"
 )
 
-//go:embed all:templates
 // FS is the embedded filesystem containing template files.
+//
+//go:embed all:templates
 var FS embed.FS
 
 // GetTemplate reads a template file from the embedded filesystem.
@@ -15,6 +18,26 @@ func GetTemplate(name string) ([]byte, error) {
 	return FS.ReadFile(filepath.Join("templates", name))
 }
 
+// Render reads a template file from the embedded filesystem and executes it
+// as a text/template against data, returning the rendered output.
+func Render(name string, data interface{}) (string, error) {
+	content, err := GetTemplate(name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(filepath.Base(name)).Parse(string(content))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // ReadDir reads a directory from the embedded filesystem.
 func ReadDir(name string) ([]string, error) {
 	entries, err := FS.ReadDir(filepath.Join("templates", name))
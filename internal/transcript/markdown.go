@@ -0,0 +1,98 @@
+package transcript
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxCleanLength bounds how long a cleaned block of text is rendered to
+// before notification space runs out.
+const maxCleanLength = 200
+
+var (
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern  = regexp.MustCompile(`\*(.+?)\*`)
+	codePattern    = regexp.MustCompile("`([^`]+)`")
+	linkPattern    = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	headingPattern = regexp.MustCompile(`^#+\s*`)
+	listPattern    = regexp.MustCompile(`^\s*(?:[-*]|\d+\.)\s+`)
+	fencePattern   = regexp.MustCompile("^```")
+)
+
+// cleanMarkdown renders a transcript text block down to plain text suitable
+// for a desktop notification: code fences are unwrapped, list items get a
+// plain bullet, links keep their label, inline emphasis markers are
+// stripped, and the result is truncated to maxCleanLength without cutting a
+// word in half. This replaces the previous approach of taking only the
+// first line and stripping markdown with a handful of regexes, which lost
+// everything past the first newline.
+func cleanMarkdown(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	var out []string
+	inFence := false
+	blank := false
+	for _, line := range lines {
+		if fencePattern.MatchString(strings.TrimSpace(line)) {
+			inFence = !inFence
+			continue
+		}
+
+		if inFence {
+			out = append(out, line)
+			blank = false
+			continue
+		}
+
+		line = headingPattern.ReplaceAllString(line, "")
+		if listPattern.MatchString(line) {
+			line = listPattern.ReplaceAllString(line, "• ")
+		}
+		line = stripInline(line)
+
+		if strings.TrimSpace(line) == "" {
+			if blank {
+				continue // collapse consecutive blank lines
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, line)
+	}
+
+	cleaned := strings.TrimSpace(strings.Join(out, "\n"))
+	return truncate(cleaned, maxCleanLength)
+}
+
+// stripInline strips bold/italic/code emphasis markers and collapses
+// [text](url) links down to their label, keeping the visible text intact.
+func stripInline(line string) string {
+	line = linkPattern.ReplaceAllString(line, "$1")
+	line = boldPattern.ReplaceAllString(line, "$1")
+	line = codePattern.ReplaceAllString(line, "$1")
+	line = italicPattern.ReplaceAllString(line, "$1")
+	return line
+}
+
+// truncate cuts s to at most maxLen runes, backing up to the last word
+// boundary so it doesn't end mid-word, and appends "..." if it cut anything.
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+
+	cut := maxLen - 3
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && runes[cut] != ' ' && runes[cut] != '\n' {
+		cut--
+	}
+	if cut == 0 {
+		cut = maxLen - 3
+	}
+
+	return strings.TrimSpace(string(runes[:cut])) + "..."
+}
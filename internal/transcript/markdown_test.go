@@ -0,0 +1,73 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanMarkdownStripsInlineEmphasisAndLinks(t *testing.T) {
+	got := cleanMarkdown("**bold** and *italic* and `code` and [label](https://example.com)")
+	want := "bold and italic and code and label"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCleanMarkdownPreservesFencedCodeBlocks(t *testing.T) {
+	got := cleanMarkdown("before\n```go\nfmt.Println(\"**not bold**\")\n```\nafter")
+	want := "before\nfmt.Println(\"**not bold**\")\nafter"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCleanMarkdownConvertsListItemsToBullets(t *testing.T) {
+	got := cleanMarkdown("- first\n* second\n1. third")
+	want := "• first\n• second\n• third"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCleanMarkdownStripsHeadingMarkers(t *testing.T) {
+	got := cleanMarkdown("## Summary")
+	if got != "Summary" {
+		t.Errorf("expected 'Summary', got %q", got)
+	}
+}
+
+func TestCleanMarkdownCollapsesConsecutiveBlankLines(t *testing.T) {
+	got := cleanMarkdown("one\n\n\n\ntwo")
+	want := "one\n\ntwo"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCleanMarkdownTruncatesLongTextAtWordBoundary(t *testing.T) {
+	text := strings.Repeat("word ", 60)
+	got := cleanMarkdown(text)
+	if len(got) > maxCleanLength {
+		t.Fatalf("expected length <= %d, got %d (%q)", maxCleanLength, len(got), got)
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("expected truncated text to end with '...', got %q", got)
+	}
+	if strings.HasSuffix(strings.TrimSuffix(got, "..."), " ") {
+		t.Errorf("expected truncation to trim trailing whitespace before the ellipsis, got %q", got)
+	}
+}
+
+func TestCleanMarkdownLeavesShortTextUnchanged(t *testing.T) {
+	got := cleanMarkdown("short and sweet")
+	if got != "short and sweet" {
+		t.Errorf("expected unchanged text, got %q", got)
+	}
+}
+
+func TestTruncateDoesNotCutAWordInHalf(t *testing.T) {
+	got := truncate("one two three four five", 14)
+	if strings.HasSuffix(got, "ee...") {
+		t.Errorf("expected truncate to back up to a word boundary, got %q", got)
+	}
+}
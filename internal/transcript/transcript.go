@@ -0,0 +1,204 @@
+// Package transcript parses Claude Code session transcripts (JSONL) into a
+// typed summary, replacing line-by-line regex scraping of the raw log.
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Block is a single content block within a transcript message. Only the
+// fields relevant to summarization are decoded; unknown block kinds are
+// skipped rather than rejected, since the transcript format grows new block
+// kinds over time.
+type Block struct {
+	Type       string          `json:"type"` // "text", "tool_use", "tool_result", "thinking"
+	Text       string          `json:"text,omitempty"`
+	Thinking   string          `json:"thinking,omitempty"`
+	Name       string          `json:"name,omitempty"` // tool name, for tool_use blocks
+	Content    string          `json:"-"`              // tool_result content, normalized from string or []Block
+	IsError    bool            `json:"is_error,omitempty"`
+	RawContent json.RawMessage `json:"content,omitempty"`
+}
+
+// UnmarshalJSON decodes Block, then normalizes tool_result's Content field,
+// which the transcript format represents as either a plain string or a
+// nested list of blocks.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type alias Block
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*b = Block(a)
+
+	if len(b.RawContent) == 0 {
+		return nil
+	}
+	var asString string
+	if err := json.Unmarshal(b.RawContent, &asString); err == nil {
+		b.Content = asString
+		return nil
+	}
+	var asBlocks []Block
+	if err := json.Unmarshal(b.RawContent, &asBlocks); err == nil {
+		var parts []string
+		for _, nested := range asBlocks {
+			if nested.Text != "" {
+				parts = append(parts, nested.Text)
+			}
+		}
+		b.Content = strings.Join(parts, "\n")
+	}
+	return nil
+}
+
+// Usage reports token counts for a single assistant message.
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Message is the payload of a transcript entry.
+type Message struct {
+	Role    string  `json:"role"`
+	Content []Block `json:"content"`
+	Usage   *Usage  `json:"usage,omitempty"`
+}
+
+// Entry is a single line of a transcript JSONL file.
+type Entry struct {
+	Type      string    `json:"type"` // "user", "assistant", "system", ...
+	Message   Message   `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Summary is computed across an entire transcript, for rendering a richer
+// Stop notification than just the last assistant line.
+type Summary struct {
+	FinalText  string   `json:"final_text"`
+	ToolsUsed  []string `json:"tools_used,omitempty"`
+	LastError  string   `json:"last_error,omitempty"`
+	TokensIn   int      `json:"tokens_in"`
+	TokensOut  int      `json:"tokens_out"`
+	DurationMS int64    `json:"duration_ms"`
+}
+
+// Summarize streams a transcript JSONL file and computes a Summary across
+// every entry: the final assistant text, every distinct tool invoked, the
+// last tool error seen, total token usage, and wall-clock duration derived
+// from the first and last entry timestamps (0 if entries carry no
+// timestamps). Malformed lines are skipped rather than failing the whole
+// parse, matching how the hook layer previously tolerated partial/corrupt
+// transcripts.
+func Summarize(r io.Reader) (*Summary, error) {
+	summary := &Summary{}
+	seenTools := make(map[string]bool)
+	var firstTime, lastTime time.Time
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+
+		if !entry.Timestamp.IsZero() {
+			if firstTime.IsZero() {
+				firstTime = entry.Timestamp
+			}
+			lastTime = entry.Timestamp
+		}
+
+		if entry.Message.Usage != nil {
+			summary.TokensIn += entry.Message.Usage.InputTokens
+			summary.TokensOut += entry.Message.Usage.OutputTokens
+		}
+
+		for _, block := range entry.Message.Content {
+			switch block.Type {
+			case "text":
+				if entry.Type == "assistant" && block.Text != "" {
+					summary.FinalText = cleanMarkdown(block.Text)
+				}
+			case "tool_use":
+				if block.Name != "" && !seenTools[block.Name] {
+					seenTools[block.Name] = true
+					summary.ToolsUsed = append(summary.ToolsUsed, block.Name)
+				}
+			case "tool_result":
+				if block.IsError && block.Content != "" {
+					summary.LastError = cleanMarkdown(block.Content)
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return summary, err
+	}
+
+	if !firstTime.IsZero() && !lastTime.IsZero() && lastTime.After(firstTime) {
+		summary.DurationMS = lastTime.Sub(firstTime).Milliseconds()
+	}
+
+	return summary, nil
+}
+
+// Headline renders a two-line notification body for summary: the cleaned
+// final text as the headline, and a subtitle like "3 tools · 12.4k tokens ·
+// 45s" summarizing the rest.
+func Headline(summary *Summary) (headline, subtitle string) {
+	headline = summary.FinalText
+	if headline == "" && summary.LastError != "" {
+		headline = summary.LastError
+	}
+
+	var parts []string
+	if n := len(summary.ToolsUsed); n > 0 {
+		parts = append(parts, pluralize(n, "tool"))
+	}
+	if total := summary.TokensIn + summary.TokensOut; total > 0 {
+		parts = append(parts, formatTokens(total))
+	}
+	if summary.DurationMS > 0 {
+		parts = append(parts, formatDuration(summary.DurationMS))
+	}
+	subtitle = strings.Join(parts, " · ")
+
+	return headline, subtitle
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+func formatTokens(total int) string {
+	if total < 1000 {
+		return strconv.Itoa(total) + " tokens"
+	}
+	return fmt.Sprintf("%.1fk tokens", float64(total)/1000)
+}
+
+func formatDuration(ms int64) string {
+	seconds := ms / 1000
+	if seconds < 60 {
+		return strconv.Itoa(int(seconds)) + "s"
+	}
+	minutes := seconds / 60
+	remainder := seconds % 60
+	return fmt.Sprintf("%dm%ds", minutes, remainder)
+}
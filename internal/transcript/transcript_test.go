@@ -0,0 +1,122 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummarizeComputesFinalTextToolsErrorAndTokens(t *testing.T) {
+	lines := []string{
+		`{"type":"user","timestamp":"2026-01-01T09:00:00Z","message":{"role":"user","content":[{"type":"text","text":"run the tests"}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-01T09:00:05Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash"}],"usage":{"input_tokens":100,"output_tokens":20}}}`,
+		`{"type":"user","timestamp":"2026-01-01T09:00:10Z","message":{"role":"user","content":[{"type":"tool_result","is_error":true,"content":"exit status 1"}]}}`,
+		`{"type":"assistant","timestamp":"2026-01-01T09:00:45Z","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash"},{"type":"text","text":"**Fixed** the failing test"}],"usage":{"input_tokens":200,"output_tokens":40}}}`,
+	}
+
+	summary, err := Summarize(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+
+	if summary.FinalText != "Fixed the failing test" {
+		t.Errorf("expected cleaned final text, got %q", summary.FinalText)
+	}
+	if len(summary.ToolsUsed) != 1 || summary.ToolsUsed[0] != "Bash" {
+		t.Errorf("expected a single deduplicated tool 'Bash', got %v", summary.ToolsUsed)
+	}
+	if summary.LastError != "exit status 1" {
+		t.Errorf("expected last error 'exit status 1', got %q", summary.LastError)
+	}
+	if summary.TokensIn != 300 || summary.TokensOut != 60 {
+		t.Errorf("expected tokens in=300 out=60, got in=%d out=%d", summary.TokensIn, summary.TokensOut)
+	}
+	if summary.DurationMS != 45000 {
+		t.Errorf("expected duration 45000ms, got %d", summary.DurationMS)
+	}
+}
+
+func TestSummarizeSkipsMalformedLinesAndBlankLines(t *testing.T) {
+	input := "not json\n\n" + `{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"ok"}]}}`
+
+	summary, err := Summarize(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if summary.FinalText != "ok" {
+		t.Errorf("expected final text 'ok', got %q", summary.FinalText)
+	}
+}
+
+func TestSummarizeZeroDurationWithoutTimestamps(t *testing.T) {
+	input := `{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"done"}]}}`
+
+	summary, err := Summarize(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if summary.DurationMS != 0 {
+		t.Errorf("expected zero duration without timestamps, got %d", summary.DurationMS)
+	}
+}
+
+func TestBlockUnmarshalJSONNormalizesStringToolResultContent(t *testing.T) {
+	var b Block
+	if err := b.UnmarshalJSON([]byte(`{"type":"tool_result","content":"plain string"}`)); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if b.Content != "plain string" {
+		t.Errorf("expected Content 'plain string', got %q", b.Content)
+	}
+}
+
+func TestBlockUnmarshalJSONNormalizesNestedBlockToolResultContent(t *testing.T) {
+	var b Block
+	data := `{"type":"tool_result","content":[{"type":"text","text":"line one"},{"type":"text","text":"line two"}]}`
+	if err := b.UnmarshalJSON([]byte(data)); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if b.Content != "line one\nline two" {
+		t.Errorf("expected joined nested text, got %q", b.Content)
+	}
+}
+
+func TestHeadlineFallsBackToLastErrorWhenNoFinalText(t *testing.T) {
+	summary := &Summary{LastError: "boom"}
+	headline, _ := Headline(summary)
+	if headline != "boom" {
+		t.Errorf("expected headline to fall back to the last error, got %q", headline)
+	}
+}
+
+func TestHeadlineBuildsSubtitleFromToolsTokensAndDuration(t *testing.T) {
+	summary := &Summary{
+		FinalText:  "done",
+		ToolsUsed:  []string{"Bash", "Edit", "Read"},
+		TokensIn:   10000,
+		TokensOut:  2400,
+		DurationMS: 45000,
+	}
+	headline, subtitle := Headline(summary)
+	if headline != "done" {
+		t.Errorf("expected headline 'done', got %q", headline)
+	}
+	if subtitle != "3 tools · 12.4k tokens · 45s" {
+		t.Errorf("unexpected subtitle %q", subtitle)
+	}
+}
+
+func TestHeadlineSingularizesOneTool(t *testing.T) {
+	summary := &Summary{FinalText: "done", ToolsUsed: []string{"Bash"}}
+	_, subtitle := Headline(summary)
+	if subtitle != "1 tool" {
+		t.Errorf("expected '1 tool', got %q", subtitle)
+	}
+}
+
+func TestHeadlineFormatsMinutesWhenOverAMinute(t *testing.T) {
+	summary := &Summary{FinalText: "done", DurationMS: 90000}
+	_, subtitle := Headline(summary)
+	if subtitle != "1m30s" {
+		t.Errorf("expected '1m30s', got %q", subtitle)
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ryantking/agentctl/internal/inbox"
+)
+
+type inboxTableModel struct {
+	table table.Model
+	items []inbox.Item
+}
+
+func (m inboxTableModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m inboxTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			return m, tea.Quit
+		}
+	}
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m inboxTableModel) View() string {
+	if len(m.items) == 0 {
+		return "\n  No notifications found.\n\n"
+	}
+	return "\n" + tableStyle.Render(m.table.View()) + "\n"
+}
+
+// ShowInboxTable renders items in an interactive, scrollable table.
+func ShowInboxTable(items []inbox.Item) error {
+	columns := []table.Column{
+		{Title: "ID", Width: 5},
+		{Title: "Read", Width: 6},
+		{Title: "Type", Width: 8},
+		{Title: "Title", Width: 50},
+		{Title: "Reason", Width: 20},
+	}
+
+	rows := make([]table.Row, len(items))
+	for i, item := range items {
+		readText := "unread"
+		if item.Read {
+			readText = "read"
+		}
+		if item.Pinned {
+			readText = "* " + readText
+		}
+
+		rows[i] = table.Row{
+			fmt.Sprint(item.ID),
+			readText,
+			item.Type,
+			item.Title,
+			item.Reason,
+		}
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(min(len(items)+2, 20)),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = selectedStyle
+	s.Cell = lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+	t.SetStyles(s)
+
+	m := inboxTableModel{
+		table: t,
+		items: items,
+	}
+
+	if _, err := tea.NewProgram(m, tea.WithOutput(os.Stderr)).Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
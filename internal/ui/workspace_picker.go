@@ -2,6 +2,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,7 +12,7 @@ import (
 )
 
 // GetWorkspaceArg gets workspace name from args or prompts user to pick one using fzf.
-func GetWorkspaceArg(args []string, workspaces []workspace.Workspace) (string, error) {
+func GetWorkspaceArg(ctx context.Context, args []string, workspaces []workspace.Workspace) (string, error) {
 	if len(args) > 0 && args[0] != "" {
 		return args[0], nil
 	}
@@ -23,7 +24,7 @@ func GetWorkspaceArg(args []string, workspaces []workspace.Workspace) (string, e
 
 	// Try to use fzf if available
 	if fzfAvailable() {
-		return pickWorkspaceWithFzf(workspaces)
+		return pickWorkspaceWithFzf(ctx, workspaces)
 	}
 
 	// No fzf available, require branch name
@@ -37,7 +38,7 @@ func fzfAvailable() bool {
 }
 
 // pickWorkspaceWithFzf uses fzf to let user select a workspace.
-func pickWorkspaceWithFzf(workspaces []workspace.Workspace) (string, error) {
+func pickWorkspaceWithFzf(ctx context.Context, workspaces []workspace.Workspace) (string, error) {
 	if len(workspaces) == 0 {
 		return "", fmt.Errorf("no workspaces available")
 	}
@@ -61,7 +62,7 @@ func pickWorkspaceWithFzf(workspaces []workspace.Workspace) (string, error) {
 	input := strings.Join(lines, "\n")
 
 	// Run fzf with custom preview
-	cmd := exec.Command("fzf",
+	cmd := exec.CommandContext(ctx, "fzf",
 		"--height", "40%",
 		"--border",
 		"--header", "Select workspace (use arrow keys, type to filter)",
@@ -25,7 +25,7 @@ var (
 )
 
 type workspaceTableModel struct {
-	table    table.Model
+	table      table.Model
 	workspaces []workspace.Workspace
 }
 
@@ -60,6 +60,7 @@ func ShowWorkspaceTable(workspaces []workspace.Workspace) error {
 		{Title: "Branch", Width: 30},
 		{Title: "Status", Width: 12},
 		{Title: "Commit", Width: 10},
+		{Title: "PR", Width: 40},
 		{Title: "Path", Width: 50},
 	}
 
@@ -77,10 +78,18 @@ func ShowWorkspaceTable(workspaces []workspace.Workspace) error {
 			branch = "detached"
 		}
 
+		prURL := "-"
+		if w.Branch != "" && w.RepoRoot != "" {
+			if meta, err := workspace.LoadWorkspaceMetadata(w.RepoRoot, w.Branch); err == nil && meta.PRURL != "" {
+				prURL = meta.PRURL
+			}
+		}
+
 		rows[i] = table.Row{
 			branch,
 			statusText,
 			w.Commit,
+			prURL,
 			w.Path,
 		}
 	}
@@ -0,0 +1,127 @@
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/manifest"
+)
+
+// MergeResult is the outcome of applying a three-way merge to one lockfile
+// item.
+type MergeResult struct {
+	Entry    LockEntry
+	Merged   []byte
+	Conflict bool
+}
+
+// Apply three-way merges every lockfile entry against its current template,
+// writes the result back to disk, and records the new lockfile. Entries
+// that could not be merged cleanly are written with conflict markers and
+// keep their previous recorded hash, so the next Check still flags them.
+func Apply(target string, mf *manifest.Manifest) ([]MergeResult, error) {
+	lf, err := Load(target)
+	if err != nil {
+		return nil, err
+	}
+
+	mcpPath := filepath.Join(target, ".mcp.json")
+	var mcpConfig map[string]interface{}
+	mcpDirty := false
+
+	results := make([]MergeResult, 0, len(lf.Items))
+	updatedItems := make([]LockEntry, 0, len(lf.Items))
+
+	for _, entry := range lf.Items {
+		theirs, err := theirsFor(entry, mf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read current template for %s: %w", entry.Path, err)
+		}
+
+		ours, err := oursFor(target, entry, theirs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Path, err)
+		}
+
+		merged, conflict := ThreeWayMerge(entry.SHA256, ours, theirs)
+		newEntry := entry
+
+		if entry.Kind == "mcp_server" {
+			if !conflict {
+				if mcpConfig == nil {
+					mcpConfig, err = loadMCPConfig(mcpPath)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if err := setMCPServer(mcpConfig, strings.TrimPrefix(entry.Path, ".mcp.json#"), merged); err != nil {
+					return nil, fmt.Errorf("failed to apply MCP server %s: %w", entry.Path, err)
+				}
+				mcpDirty = true
+				newEntry.SHA256 = hashOf(merged)
+			}
+		} else {
+			dest := filepath.Join(target, entry.Path)
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(dest, merged, 0644); err != nil { //nolint:gosec // Destination mirrors the original install
+				return nil, fmt.Errorf("failed to write %s: %w", entry.Path, err)
+			}
+			if !conflict {
+				newEntry.SHA256 = hashOf(merged)
+			}
+		}
+
+		results = append(results, MergeResult{Entry: entry, Merged: merged, Conflict: conflict})
+		updatedItems = append(updatedItems, newEntry)
+	}
+
+	if mcpDirty {
+		data, err := config.SaveJSON(mcpConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(mcpPath, append(data, '\n'), 0644); err != nil { //nolint:gosec // MCP config needs to be readable
+			return nil, err
+		}
+	}
+
+	lf.Items = updatedItems
+	if err := Save(target, lf); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func loadMCPConfig(mcpPath string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(mcpPath) //nolint:gosec // Path is derived from the repo root
+	if os.IsNotExist(err) {
+		return map[string]interface{}{"mcpServers": map[string]interface{}{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cfg["mcpServers"]; !ok {
+		cfg["mcpServers"] = map[string]interface{}{}
+	}
+	return cfg, nil
+}
+
+func setMCPServer(cfg map[string]interface{}, name string, serverJSON []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(serverJSON, &value); err != nil {
+		return err
+	}
+	cfg["mcpServers"].(map[string]interface{})[name] = value
+	return nil
+}
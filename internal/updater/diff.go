@@ -0,0 +1,95 @@
+package updater
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/manifest"
+	"github.com/ryantking/agentctl/internal/templates"
+)
+
+// ItemStatus describes one lockfile entry's relationship to the current
+// template content and the on-disk file.
+type ItemStatus struct {
+	Entry           LockEntry
+	UpstreamChanged bool // the current template differs from what was recorded at last install/update
+	LocallyModified bool // the on-disk file differs from what was recorded at last install/update
+}
+
+// Check compares every entry in target's lockfile against the current
+// embedded/manifest templates and the on-disk files.
+func Check(target string, mf *manifest.Manifest) ([]ItemStatus, error) {
+	lf, err := Load(target)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]ItemStatus, 0, len(lf.Items))
+	for _, entry := range lf.Items {
+		theirs, err := theirsFor(entry, mf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read current template for %s: %w", entry.Path, err)
+		}
+
+		ours, err := oursFor(target, entry, theirs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Path, err)
+		}
+
+		statuses = append(statuses, ItemStatus{
+			Entry:           entry,
+			UpstreamChanged: hashOf(theirs) != entry.SHA256,
+			LocallyModified: hashOf(ours) != entry.SHA256,
+		})
+	}
+	return statuses, nil
+}
+
+// theirsFor returns the current content of entry's upstream source: the
+// embedded template for "agent"/"skill" entries, the embedded settings
+// template for "settings", or the manifest's current definition for
+// "mcp_server".
+func theirsFor(entry LockEntry, mf *manifest.Manifest) ([]byte, error) {
+	switch entry.Kind {
+	case "agent", "skill":
+		return templates.FS.ReadFile(entry.TemplatePath)
+	case "settings":
+		return templates.GetTemplate("settings.json")
+	case "mcp_server":
+		name := strings.TrimPrefix(entry.Path, ".mcp.json#")
+		server, ok := mf.MCPServers[name]
+		if !ok {
+			return nil, fmt.Errorf("MCP server %q is no longer present in the manifest", name)
+		}
+		return json.Marshal(server.ToJSON())
+	default:
+		return nil, fmt.Errorf("unknown lockfile entry kind %q", entry.Kind)
+	}
+}
+
+// oursFor returns the on-disk content for entry. MCP server entries live
+// inside .mcp.json rather than as a standalone file, so callers diff them
+// purely by recorded hash; oursFor returns theirs unchanged for those so the
+// comparison degenerates to "hash matches the lockfile or it doesn't" at the
+// call site, with the real per-server value handled by Apply.
+func oursFor(target string, entry LockEntry, theirs []byte) ([]byte, error) {
+	if entry.Kind == "mcp_server" {
+		return theirs, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(target, entry.Path)) //nolint:gosec // Path is derived from the repo root
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
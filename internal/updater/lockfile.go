@@ -0,0 +1,63 @@
+// Package updater detects and applies upstream changes to the Claude
+// templates `agentctl init` installed, using a lockfile to tell local edits
+// apart from drift in the embedded/manifest templates.
+package updater
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// LockEntry records the provenance of a single file or MCP server entry
+// that setup.Manager.Install wrote.
+type LockEntry struct {
+	Path         string `json:"path"`                    // relative to the repo root; ".mcp.json#<name>" for MCP servers
+	Kind         string `json:"kind"`                    // "agent", "skill", "settings", or "mcp_server"
+	Source       string `json:"source,omitempty"`        // manifest source, e.g. "embedded"
+	Version      string `json:"version,omitempty"`       // manifest version, if pinned
+	TemplatePath string `json:"template_path,omitempty"` // path into templates.FS, for "agent"/"skill" entries
+	SHA256       string `json:"sha256"`
+}
+
+// Lockfile is the `.claude/agentctl.lock` schema.
+type Lockfile struct {
+	ToolVersion string      `json:"tool_version"`
+	Items       []LockEntry `json:"items"`
+}
+
+// Path returns the path to the lockfile under target.
+func Path(target string) string {
+	return filepath.Join(target, ".claude", "agentctl.lock")
+}
+
+// Load reads the lockfile, returning an empty Lockfile if none exists yet
+// (e.g. the repo was initialized before `agentctl update` existed).
+func Load(target string) (*Lockfile, error) {
+	data, err := os.ReadFile(Path(target)) //nolint:gosec // Path is derived from the repo root
+	if os.IsNotExist(err) {
+		return &Lockfile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lf Lockfile
+	if err := json.Unmarshal(data, &lf); err != nil {
+		return nil, err
+	}
+	return &lf, nil
+}
+
+// Save writes lf to the lockfile under target.
+func Save(target string, lf *Lockfile) error {
+	if err := os.MkdirAll(filepath.Dir(Path(target)), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(target), append(data, '\n'), 0644) //nolint:gosec // Lockfile needs to be readable
+}
@@ -0,0 +1,40 @@
+package updater
+
+// ThreeWayMerge resolves one lockfile entry's new content given baseSHA256
+// (the hash recorded at the last install/update), ours (the on-disk
+// content), and theirs (the current template content). When only one side
+// has moved since base, the other side's change wins outright. When both
+// sides have moved and disagree, it reports a conflict rather than guessing
+// at a line-level merge, with git-style conflict markers in the returned
+// bytes for manual resolution.
+func ThreeWayMerge(baseSHA256 string, ours, theirs []byte) (merged []byte, conflict bool) {
+	oursHash := hashOf(ours)
+	theirsHash := hashOf(theirs)
+
+	switch {
+	case oursHash == baseSHA256:
+		return theirs, false
+	case theirsHash == baseSHA256:
+		return ours, false
+	case oursHash == theirsHash:
+		return ours, false
+	}
+
+	return conflictMarkers(ours, theirs), true
+}
+
+func conflictMarkers(ours, theirs []byte) []byte {
+	var merged []byte
+	merged = append(merged, "<<<<<<< ours\n"...)
+	merged = append(merged, ours...)
+	if len(ours) == 0 || ours[len(ours)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	merged = append(merged, "=======\n"...)
+	merged = append(merged, theirs...)
+	if len(theirs) == 0 || theirs[len(theirs)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	merged = append(merged, ">>>>>>> theirs\n"...)
+	return merged
+}
@@ -0,0 +1,105 @@
+package updater
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/remote"
+)
+
+// BranchPrefix is prepended to the date-stamped branch `update --pr`
+// commits merged template updates to.
+const BranchPrefix = "agentctl/update-"
+
+// CommitUpdateBranch creates a new branch named BranchPrefix+dateStamp from
+// HEAD, stages every file Apply touched, and commits them.
+func CommitUpdateBranch(target, dateStamp, message string) (string, error) {
+	branch := BranchPrefix + dateStamp
+
+	repo, err := gogit.PlainOpen(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, head.Hash())); err != nil {
+		return "", fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	if err := wt.Checkout(&gogit.CheckoutOptions{Branch: branchRef}); err != nil {
+		return "", fmt.Errorf("failed to check out branch %s: %w", branch, err)
+	}
+
+	if err := wt.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+		return "", fmt.Errorf("failed to stage updated files: %w", err)
+	}
+
+	sig := &object.Signature{Name: "agentctl", Email: "agentctl@localhost", When: time.Now()}
+	if _, err := wt.Commit(message, &gogit.CommitOptions{Author: sig}); err != nil {
+		return "", fmt.Errorf("failed to commit updated files: %w", err)
+	}
+
+	return branch, nil
+}
+
+// OpenUpdatePR pushes branch and opens a pull/merge request against base.
+// With an empty platform it shells out to `gh pr create`, mirroring how
+// hook.getPRStatus already shells to gh for read-only PR status; with a
+// platform name it uses the matching remote.Provider API instead.
+func OpenUpdatePR(ctx context.Context, target, branch, base, title, body, platform string) (*remote.PR, error) {
+	if err := git.Push(target, "origin", branch); err != nil {
+		return nil, err
+	}
+
+	if platform == "" {
+		return openPRViaGH(target, branch, base, title, body)
+	}
+
+	provider, err := remote.ProviderByName(platform, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.OpenPullRequest(ctx, target, remote.PROptions{
+		Branch: branch,
+		Base:   base,
+		Title:  title,
+		Body:   body,
+	})
+}
+
+func openPRViaGH(target, branch, base, title, body string) (*remote.PR, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return nil, fmt.Errorf("gh CLI not found: %w", err)
+	}
+
+	args := []string{"pr", "create", "--head", branch, "--title", title, "--body", body}
+	if base != "" {
+		args = append(args, "--base", base)
+	}
+
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = target
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh pr create failed: %w", err)
+	}
+
+	return &remote.PR{URL: strings.TrimSpace(string(out))}, nil
+}
@@ -0,0 +1,146 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// CheckoutOptions controls CheckoutWorkspace's behavior.
+type CheckoutOptions struct {
+	// Create, if non-empty, creates a new branch with this name from the
+	// workspace's current HEAD instead of switching to an existing ref.
+	Create string
+	// Hash, if set, detaches to this commit instead of checking out a branch.
+	Hash string
+	// Force discards conflicting local changes instead of failing.
+	Force bool
+}
+
+// CheckoutWorkspace checks out a ref in an existing workspace's worktree
+// using go-git directly. With no options it re-attaches the worktree to its
+// own branch (useful after a prior detached checkout); opts.Hash detaches
+// to a specific commit; opts.Create makes a new branch from the current
+// HEAD instead.
+func (m *WorkspaceManager) CheckoutWorkspace(branch string, opts CheckoutOptions) error {
+	ctx, cancel := m.lockContext()
+	defer cancel()
+
+	return m.WithLock(ctx, LockExclusive, func() error {
+		return m.checkoutWorkspace(branch, opts)
+	})
+}
+
+func (m *WorkspaceManager) checkoutWorkspace(branch string, opts CheckoutOptions) error {
+	ws, err := m.GetWorkspace(branch)
+	if err != nil {
+		return err
+	}
+
+	repo, err := git.OpenRepo(context.Background(), ws.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	checkoutOpts := &gogit.CheckoutOptions{Force: opts.Force}
+
+	switch {
+	case opts.Hash != "":
+		checkoutOpts.Hash = plumbing.NewHash(opts.Hash)
+	case opts.Create != "":
+		checkoutOpts.Branch = plumbing.NewBranchReferenceName(opts.Create)
+		checkoutOpts.Create = true
+	default:
+		checkoutOpts.Branch = plumbing.NewBranchReferenceName(branch)
+	}
+
+	if err := worktree.Checkout(checkoutOpts); err != nil {
+		return fmt.Errorf("failed to checkout: %w", err)
+	}
+
+	return nil
+}
+
+// ResetOptions controls ResetWorkspace's behavior.
+type ResetOptions struct {
+	Mode gogit.ResetMode
+	// To is the commit-ish to reset to; empty means the workspace's current
+	// HEAD, i.e. discard uncommitted changes in place.
+	To string
+	// Force allows a hard reset even if the workspace has uncommitted changes.
+	Force bool
+}
+
+// ResetWorkspace resets an existing workspace's worktree to opts.To (or its
+// current HEAD) using opts.Mode, without shelling out to git. A hard reset
+// is refused when the workspace has uncommitted changes unless opts.Force is
+// set, mirroring the guardrail deleteWorkspace already applies.
+func (m *WorkspaceManager) ResetWorkspace(branch string, opts ResetOptions) error {
+	ctx, cancel := m.lockContext()
+	defer cancel()
+
+	return m.WithLock(ctx, LockExclusive, func() error {
+		return m.resetWorkspace(branch, opts)
+	})
+}
+
+func (m *WorkspaceManager) resetWorkspace(branch string, opts ResetOptions) error {
+	ws, err := m.GetWorkspace(branch)
+	if err != nil {
+		return err
+	}
+
+	if opts.Mode == gogit.HardReset && !opts.Force {
+		isClean, status := ws.IsClean()
+		if !isClean {
+			return fmt.Errorf("workspace has uncommitted changes (%s). Use --force to discard them", status)
+		}
+	}
+
+	repo, err := git.OpenRepo(context.Background(), ws.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	commit, err := resolveCommit(repo, opts.To)
+	if err != nil {
+		return err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := worktree.Reset(&gogit.ResetOptions{Mode: opts.Mode, Commit: commit}); err != nil {
+		return fmt.Errorf("failed to reset: %w", err)
+	}
+
+	return nil
+}
+
+// resolveCommit resolves ref to a commit hash, defaulting to HEAD when ref
+// is empty.
+func resolveCommit(repo *git.Repo, ref string) (plumbing.Hash, error) {
+	if ref == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to resolve HEAD: %w", err)
+		}
+		return head.Hash(), nil
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return *hash, nil
+}
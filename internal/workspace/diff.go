@@ -0,0 +1,298 @@
+package workspace
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// FileStatus describes how a file changed between two trees.
+type FileStatus string
+
+const (
+	// FileAdded indicates the file did not exist in the base tree.
+	FileAdded FileStatus = "added"
+	// FileModified indicates the file exists in both trees with different content.
+	FileModified FileStatus = "modified"
+	// FileDeleted indicates the file existed in the base tree but not the target.
+	FileDeleted FileStatus = "deleted"
+	// FileRenamed indicates an added and a deleted entry were paired as a rename.
+	FileRenamed FileStatus = "renamed"
+	// FileCopied indicates an added entry was paired with a still-present
+	// source. GetWorkspaceDiffStructured never assigns it.
+	FileCopied FileStatus = "copied"
+)
+
+// DiffEntry describes a single file's change.
+type DiffEntry struct {
+	Path      string
+	OldPath   string // set for FileRenamed/FileCopied
+	Status    FileStatus
+	Additions int
+	Deletions int
+	IsBinary  bool
+	Hunks     []string
+}
+
+// DiffStats aggregates totals across all entries in a DiffResult.
+type DiffStats struct {
+	FilesChanged int
+	Additions    int
+	Deletions    int
+}
+
+// DiffResult is the structured output of GetWorkspaceDiffStructured.
+type DiffResult struct {
+	Entries []DiffEntry
+	Stats   DiffStats
+}
+
+// DiffOptions controls how GetWorkspaceDiffStructured computes its result.
+type DiffOptions struct {
+	// FindRenames enables pairing Added/Deleted entries into Renamed ones
+	// by file-name similarity. File contents are not compared, and no
+	// entry is marked Copied.
+	FindRenames bool
+	// SimilarityThreshold is the minimum similarity (0-100, see
+	// similarityScore) of the two paths' base names required to treat an
+	// add/delete pair as a rename. Defaults to 50 when zero.
+	SimilarityThreshold int
+}
+
+const defaultSimilarityThreshold = 50
+
+// GetWorkspaceDiffStructured returns a structured, per-file diff between a
+// workspace's HEAD and targetBranch, with optional rename/copy detection.
+func (m *WorkspaceManager) GetWorkspaceDiffStructured(workspace *Workspace, targetBranch string, opts DiffOptions) (*DiffResult, error) {
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD tree: %w", err)
+	}
+
+	targetRef, err := repo.Reference(plumbing.NewBranchReferenceName(targetBranch), true)
+	if err != nil {
+		return nil, fmt.Errorf("target branch %s not found: %w", targetBranch, err)
+	}
+	targetCommit, err := repo.CommitObject(targetRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target commit: %w", err)
+	}
+	targetTree, err := targetCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target tree: %w", err)
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), targetTree, headTree, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff trees: %w", err)
+	}
+
+	entries := make([]DiffEntry, 0, len(changes))
+	for _, change := range changes {
+		entry, err := buildDiffEntry(change)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if opts.FindRenames {
+		threshold := opts.SimilarityThreshold
+		if threshold <= 0 {
+			threshold = defaultSimilarityThreshold
+		}
+		entries = pairRenames(repo, entries, threshold)
+	}
+
+	return &DiffResult{Entries: entries, Stats: aggregateDiffStats(entries)}, nil
+}
+
+func buildDiffEntry(change *object.Change) (DiffEntry, error) {
+	action, err := change.Action()
+	if err != nil {
+		return DiffEntry{}, err
+	}
+
+	entry := DiffEntry{}
+	switch action {
+	case merkletrie.Insert:
+		entry.Status = FileAdded
+		entry.Path = change.To.Name
+	case merkletrie.Delete:
+		entry.Status = FileDeleted
+		entry.Path = change.From.Name
+	default:
+		entry.Status = FileModified
+		entry.Path = change.To.Name
+	}
+
+	patch, err := change.Patch()
+	if err != nil {
+		return entry, nil //nolint:nilerr // Best-effort stats; entry is still usable without them
+	}
+
+	for _, filePatch := range patch.FilePatches() {
+		if filePatch.IsBinary() {
+			entry.IsBinary = true
+			continue
+		}
+		for _, chunk := range filePatch.Chunks() {
+			lines := strings.Count(chunk.Content(), "\n")
+			switch chunk.Type() {
+			case diff.Add:
+				entry.Additions += lines
+			case diff.Delete:
+				entry.Deletions += lines
+			}
+		}
+	}
+	entry.Hunks = []string{patch.String()}
+
+	return entry, nil
+}
+
+// pairRenames matches Added and Deleted entries whose file names are
+// similar enough (see similarityScore) to be treated as a rename, marks the
+// Added entry Renamed and drops its Deleted partner. Every match is
+// reported as a rename; copies are not detected.
+func pairRenames(repo *git.Repo, entries []DiffEntry, threshold int) []DiffEntry {
+	var added, deleted []int
+	for i, e := range entries {
+		switch e.Status {
+		case FileAdded:
+			added = append(added, i)
+		case FileDeleted:
+			deleted = append(deleted, i)
+		}
+	}
+
+	matchedDeleted := make(map[int]bool)
+	for _, ai := range added {
+		bestIdx := -1
+		bestScore := 0
+		for _, di := range deleted {
+			if matchedDeleted[di] {
+				continue
+			}
+			score := similarityScore(repo, entries[di].Path, entries[ai].Path)
+			if score > bestScore {
+				bestScore = score
+				bestIdx = di
+			}
+		}
+		if bestIdx != -1 && bestScore >= threshold {
+			entries[ai].Status = FileRenamed
+			entries[ai].OldPath = entries[bestIdx].Path
+			matchedDeleted[bestIdx] = true
+		}
+	}
+
+	// Drop deleted entries that were paired off as the source of a rename.
+	result := make([]DiffEntry, 0, len(entries))
+	for i, e := range entries {
+		if e.Status == FileDeleted && matchedDeleted[i] {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// similarityScore returns a 0-100 similarity between oldPath and newPath
+// computed from their base names only: 100 when the base names are equal,
+// otherwise the Jaccard similarity of their 3-rune shingles. Blob contents
+// are not read.
+func similarityScore(_ *git.Repo, oldPath, newPath string) int {
+	if oldPath == "" || newPath == "" {
+		return 0
+	}
+	// Identical base names count as a full match.
+	oldBase, newBase := baseName(oldPath), baseName(newPath)
+	if oldBase == newBase {
+		return 100
+	}
+	oldShingles := shingles(oldBase)
+	newShingles := shingles(newBase)
+	return int(jaccard(oldShingles, newShingles) * 100)
+}
+
+func baseName(path string) string {
+	if idx := strings.LastIndex(path, "/"); idx != -1 {
+		return path[idx+1:]
+	}
+	return path
+}
+
+// shingles splits s into overlapping 3-rune windows for a cheap line/name
+// similarity signal.
+func shingles(s string) map[string]bool {
+	const windowSize = 3
+	result := make(map[string]bool)
+	runes := []rune(s)
+	if len(runes) < windowSize {
+		result[s] = true
+		return result
+	}
+	for i := 0; i+windowSize <= len(runes); i++ {
+		result[string(runes[i:i+windowSize])] = true
+	}
+	return result
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for k := range a {
+		if b[k] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+func aggregateDiffStats(entries []DiffEntry) DiffStats {
+	stats := DiffStats{FilesChanged: len(entries)}
+	for _, e := range entries {
+		stats.Additions += e.Additions
+		stats.Deletions += e.Deletions
+	}
+	return stats
+}
+
+// lineShinglesFromReader returns the set of distinct lines read from r, a
+// line-based counterpart to shingles. similarityScore does not use it.
+func lineShinglesFromReader(r io.Reader) (map[string]bool, error) {
+	shingles := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		shingles[scanner.Text()] = true
+	}
+	return shingles, scanner.Err()
+}
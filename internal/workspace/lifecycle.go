@@ -0,0 +1,62 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// Lifecycle manages the end of a workspace's life: closing it out and
+// sweeping up anything a manual `rm -rf` or a crashed process left behind.
+// It wraps a WorkspaceManager rather than duplicating its locking, and adds
+// the cleanup operations CreateWorkspace has no counterpart for.
+type Lifecycle struct {
+	manager *WorkspaceManager
+}
+
+// NewLifecycle creates a Lifecycle for the repository at repoRoot.
+func NewLifecycle(repoRoot string) (*Lifecycle, error) {
+	manager, err := NewManagerAt(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &Lifecycle{manager: manager}, nil
+}
+
+// Close removes the workspace for branch: its worktree, its directory under
+// ~/.claude/workspaces/<repo>/<branch>/, and the `.git/worktrees/<name>`
+// entry that backs it. force deletes even with uncommitted changes.
+func (l *Lifecycle) Close(ctx context.Context, branch string, force bool) error {
+	return l.manager.WithLock(ctx, LockExclusive, func() error {
+		return l.manager.deleteWorkspace(branch, force)
+	})
+}
+
+// Prune sweeps `<repoRoot>/.git/worktrees/` for entries whose gitdir file
+// points at a path that no longer exists — worktrees removed by hand
+// instead of through Close — and deletes their stale metadata. It returns
+// the names of the entries it removed.
+func (l *Lifecycle) Prune(ctx context.Context) ([]string, error) {
+	var pruned []string
+	err := l.manager.WithLock(ctx, LockExclusive, func() error {
+		var err error
+		pruned, err = git.PruneWorktrees(l.manager.repoRoot)
+		return err
+	})
+	return pruned, err
+}
+
+// DeleteWorktree removes a single `.git/worktrees/<name>` entry by its raw
+// worktree ID, regardless of whether the path it points to still exists.
+// Close and Prune do not call it; they go through deleteWorkspace and
+// git.PruneWorktrees respectively.
+func (l *Lifecycle) DeleteWorktree(name string) error {
+	worktreeDir := filepath.Join(l.manager.repoRoot, ".git", "worktrees", name)
+	if _, err := os.Stat(worktreeDir); err != nil {
+		return fmt.Errorf("worktree entry %s not found: %w", name, err)
+	}
+	return os.RemoveAll(worktreeDir)
+}
@@ -0,0 +1,197 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LockMode controls whether a lock is acquired for shared (read-only) or
+// exclusive (read-write) access.
+type LockMode int
+
+const (
+	// LockShared allows multiple concurrent holders; use for read-only
+	// operations like ListWorkspaces.
+	LockShared LockMode = iota
+	// LockExclusive allows only a single holder; use for mutating
+	// operations like CreateWorkspace, DeleteWorkspace, and CleanWorkspaces.
+	LockExclusive
+)
+
+// lockPollInterval controls how often a blocking acquire re-checks the lock
+// and re-validates staleness while waiting.
+const lockPollInterval = 100 * time.Millisecond
+
+// fileLock represents a held filesystem lock on a repo's lockfile.
+type fileLock struct {
+	path string
+	file *os.File
+}
+
+// WithLock acquires a lock for repoRoot in the given mode, runs fn, and
+// releases the lock (even if fn panics or returns an error). It blocks until
+// the lock is available or ctx is done. External callers (e.g. hooks) that
+// need to compose multiple WorkspaceManager operations atomically should
+// wrap them in a single WithLock call rather than relying on per-method
+// locking.
+func (m *WorkspaceManager) WithLock(ctx context.Context, mode LockMode, fn func() error) error {
+	lock, err := acquireLock(ctx, m.repoRoot, mode)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.release() }()
+
+	return fn()
+}
+
+// lockFilePath returns the path to the lockfile for a repo, keyed by a hash
+// of its root so worktrees of the same repo share a lock.
+func lockFilePath(repoRoot string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(repoRoot))
+	repoHash := hex.EncodeToString(sum[:])[:16]
+	return filepath.Join(home, ".claude", "workspaces", ".locks", repoHash+".lock"), nil
+}
+
+// acquireLock opens (creating if necessary) the lockfile for repoRoot and
+// acquires it in the given mode, blocking until ctx is done. Before blocking
+// it checks for a stale lock (holder PID no longer running) and reclaims it.
+func acquireLock(ctx context.Context, repoRoot string, mode LockMode) (*fileLock, error) {
+	path, err := lockFilePath(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve lock path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // Lock directory needs to be readable
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644) //nolint:gosec // Lockfile needs to be readable
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lockfile: %w", err)
+	}
+
+	lock := &fileLock{path: path, file: file}
+
+	for {
+		reclaimStaleLock(path)
+
+		if err := tryLockFile(lock.file, mode); err == nil {
+			current, verifyErr := fileStillAtPath(lock.file, path)
+			if verifyErr != nil {
+				_ = lock.release()
+				return nil, fmt.Errorf("failed to verify lockfile identity: %w", verifyErr)
+			}
+			if !current {
+				// reclaimStaleLock above (our own call, or a racing
+				// acquirer's) unlinked the inode our fd was locking out
+				// from under us, so the lock we just took doesn't exclude
+				// anyone locking the file that's actually reachable at
+				// path now. Reopen on whatever's there and try again.
+				_ = unlockFile(lock.file)
+				_ = lock.file.Close()
+
+				reopened, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644) //nolint:gosec // Lockfile needs to be readable
+				if err != nil {
+					return nil, fmt.Errorf("failed to reopen lockfile: %w", err)
+				}
+				lock.file = reopened
+				continue
+			}
+
+			if werr := writeLockHolder(lock.file); werr != nil {
+				_ = lock.release()
+				return nil, fmt.Errorf("failed to record lock holder: %w", werr)
+			}
+			return lock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = lock.file.Close()
+			return nil, fmt.Errorf("timed out waiting for workspace lock %s: %w", path, ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// fileStillAtPath reports whether file's inode is still the one currently
+// linked at path. A held flock only excludes other holders of the same
+// inode: if reclaimStaleLock removed (and something recreated) the lockfile
+// while file was already open, file's flock no longer provides real mutual
+// exclusion against a racing acquirer that opens the path fresh.
+func fileStillAtPath(file *os.File, path string) (bool, error) {
+	fdInfo, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	pathInfo, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return os.SameFile(fdInfo, pathInfo), nil
+}
+
+// writeLockHolder truncates the lockfile and writes the current process PID,
+// used for stale-lock detection by future acquirers.
+func writeLockHolder(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return err
+	}
+	_, err := file.WriteString(fmt.Sprintf("%d\n", os.Getpid()))
+	return err
+}
+
+// reclaimStaleLock reads the holder PID recorded in the lockfile at path and,
+// if that process is no longer running, removes the lockfile so a fresh one
+// can be created and locked. Errors are swallowed since this is a best-effort
+// cleanup step; a genuinely held lock will simply fail to reclaim.
+func reclaimStaleLock(path string) {
+	data, err := os.ReadFile(path) //nolint:gosec // Lockfile path is derived from repo root, not user input
+	if err != nil {
+		return
+	}
+
+	var pid int
+	if _, err := fmt.Sscanf(string(data), "%d", &pid); err != nil || pid <= 0 {
+		return
+	}
+
+	if processAlive(pid) {
+		return
+	}
+
+	// Holder is gone; best-effort reclaim. If another process wins the
+	// race to recreate and lock the file first, our subsequent tryLockFile
+	// call will simply fail and we'll loop.
+	_ = os.Remove(path)
+}
+
+// release releases the lock and closes the underlying file handle.
+func (l *fileLock) release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	closeErr := l.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,167 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAcquireLockExclusiveBlocksConcurrentHolder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	lock, err := acquireLock(ctx, "repo-a", LockExclusive)
+	if err != nil {
+		t.Fatalf("acquireLock failed: %v", err)
+	}
+	defer func() { _ = lock.release() }()
+
+	shortCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if _, err := acquireLock(shortCtx, "repo-a", LockExclusive); err == nil {
+		t.Error("expected a second exclusive acquisition to time out while the lock is held")
+	}
+}
+
+func TestAcquireLockSharedAllowsConcurrentHolders(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	first, err := acquireLock(ctx, "repo-b", LockShared)
+	if err != nil {
+		t.Fatalf("first acquireLock failed: %v", err)
+	}
+	defer func() { _ = first.release() }()
+
+	shortCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	second, err := acquireLock(shortCtx, "repo-b", LockShared)
+	if err != nil {
+		t.Fatalf("expected a second shared acquisition to succeed, got: %v", err)
+	}
+	defer func() { _ = second.release() }()
+}
+
+func TestAcquireLockReleaseAllowsReacquisition(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	lock, err := acquireLock(ctx, "repo-c", LockExclusive)
+	if err != nil {
+		t.Fatalf("acquireLock failed: %v", err)
+	}
+	if err := lock.release(); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	second, err := acquireLock(shortCtx, "repo-c", LockExclusive)
+	if err != nil {
+		t.Fatalf("expected reacquisition to succeed after release, got: %v", err)
+	}
+	_ = second.release()
+}
+
+// TestAcquireLockReclaimDoesNotBreakMutualExclusion is a regression test for
+// the race where reclaiming a stale lock removed the lockfile out from under
+// an already-open fd: the reclaiming acquirer's flock on that now-unlinked
+// inode excluded nobody, since a concurrent acquirer opening the path fresh
+// got a brand-new inode to lock instead. It races many goroutines against a
+// lockfile pre-seeded with a dead holder PID and asserts at most one ever
+// holds the exclusive lock at a time.
+func TestAcquireLockReclaimDoesNotBreakMutualExclusion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+
+	path, err := lockFilePath("repo-d")
+	if err != nil {
+		t.Fatalf("lockFilePath failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create lock dir: %v", err)
+	}
+
+	stalePID := os.Getpid() + 1_000_000
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n", stalePID)), 0644); err != nil {
+		t.Fatalf("failed to seed stale lockfile: %v", err)
+	}
+
+	const goroutines = 12
+	var current int32
+	var maxConcurrent int32
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+
+			acqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			lock, err := acquireLock(acqCtx, "repo-d", LockExclusive)
+			if err != nil {
+				t.Errorf("acquireLock failed: %v", err)
+				return
+			}
+
+			n := atomic.AddInt32(&current, 1)
+			for {
+				prevMax := atomic.LoadInt32(&maxConcurrent)
+				if n <= prevMax || atomic.CompareAndSwapInt32(&maxConcurrent, prevMax, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&current, -1)
+
+			_ = lock.release()
+		}()
+	}
+
+	wg.Wait()
+
+	if maxConcurrent > 1 {
+		t.Errorf("expected at most 1 concurrent exclusive holder, got max concurrent holders: %d", maxConcurrent)
+	}
+}
+
+func TestFileStillAtPathDetectsReplacedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/lock"
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatalf("failed to open lockfile: %v", err)
+	}
+	defer file.Close()
+
+	same, err := fileStillAtPath(file, path)
+	if err != nil {
+		t.Fatalf("fileStillAtPath failed: %v", err)
+	}
+	if !same {
+		t.Error("expected a freshly opened file to still be at its path")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove lockfile: %v", err)
+	}
+	if _, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644); err != nil {
+		t.Fatalf("failed to recreate lockfile: %v", err)
+	}
+
+	same, err = fileStillAtPath(file, path)
+	if err != nil {
+		t.Fatalf("fileStillAtPath failed: %v", err)
+	}
+	if same {
+		t.Error("expected fileStillAtPath to detect that path now points to a different inode")
+	}
+}
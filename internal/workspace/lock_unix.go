@@ -0,0 +1,34 @@
+//go:build !windows
+
+package workspace
+
+import (
+	"os"
+	"syscall"
+)
+
+// tryLockFile attempts a non-blocking flock(2) in the given mode, returning
+// an error immediately if the lock is already held elsewhere.
+func tryLockFile(file *os.File, mode LockMode) error {
+	how := syscall.LOCK_EX
+	if mode == LockShared {
+		how = syscall.LOCK_SH
+	}
+	return syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB)
+}
+
+// unlockFile releases a previously acquired flock(2) lock.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
+
+// processAlive reports whether pid refers to a currently running process.
+// On Unix, os.FindProcess always succeeds, so liveness is checked by sending
+// signal 0, which performs permission/existence checks without side effects.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
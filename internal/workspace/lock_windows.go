@@ -0,0 +1,42 @@
+//go:build windows
+
+package workspace
+
+import (
+	"os"
+	"syscall"
+)
+
+const (
+	lockfileFailImmediately = 0x00000001
+	lockfileExclusiveLock   = 0x00000002
+)
+
+// tryLockFile attempts a non-blocking LockFileEx in the given mode,
+// returning an error immediately if the lock is already held elsewhere.
+func tryLockFile(file *os.File, mode LockMode) error {
+	flags := uint32(lockfileFailImmediately)
+	if mode == LockExclusive {
+		flags |= lockfileExclusiveLock
+	}
+
+	ol := new(syscall.Overlapped)
+	return syscall.LockFileEx(syscall.Handle(file.Fd()), flags, 0, 1, 0, ol)
+}
+
+// unlockFile releases a previously acquired LockFileEx lock.
+func unlockFile(file *os.File) error {
+	ol := new(syscall.Overlapped)
+	return syscall.UnlockFileEx(syscall.Handle(file.Fd()), 0, 1, 0, ol)
+}
+
+// processAlive reports whether pid refers to a currently running process by
+// attempting to open it; OpenProcess fails once the process has exited.
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+	return true
+}
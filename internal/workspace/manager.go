@@ -1,9 +1,12 @@
 package workspace
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -11,31 +14,63 @@ import (
 	"github.com/ryantking/agentctl/internal/git"
 )
 
+// DefaultLockTimeout is how long mutating WorkspaceManager methods wait to
+// acquire the per-repo lock before giving up.
+const DefaultLockTimeout = 10 * time.Second
+
 // WorkspaceManager manages workspace lifecycle operations.
 type WorkspaceManager struct { //nolint:revive // Stuttering is acceptable for exported manager types
-	repoRoot string
+	repoRoot    string
+	lockTimeout time.Duration
 }
 
 // NewManager creates a new WorkspaceManager.
 func NewManager() (*WorkspaceManager, error) {
-	repoRoot, err := git.GetRepoRoot()
+	repoRoot, err := git.GetRepoRoot(context.Background())
 	if err != nil {
 		return nil, ErrNotInGitRepo
 	}
-	return &WorkspaceManager{repoRoot: repoRoot}, nil
+	return &WorkspaceManager{repoRoot: repoRoot, lockTimeout: DefaultLockTimeout}, nil
 }
 
 // NewManagerAt creates a new WorkspaceManager at a specific repository root.
 func NewManagerAt(repoRoot string) (*WorkspaceManager, error) {
-	return &WorkspaceManager{repoRoot: repoRoot}, nil
+	return &WorkspaceManager{repoRoot: repoRoot, lockTimeout: DefaultLockTimeout}, nil
+}
+
+// SetLockTimeout overrides how long mutating operations wait to acquire the
+// workspace lock, e.g. from a `--lock-timeout` CLI flag.
+func (m *WorkspaceManager) SetLockTimeout(timeout time.Duration) {
+	m.lockTimeout = timeout
+}
+
+// lockContext derives a context bounded by the configured lock timeout. A
+// timeout of zero blocks indefinitely, which CI runs may prefer over a
+// spurious failure under contention.
+func (m *WorkspaceManager) lockContext() (context.Context, context.CancelFunc) {
+	if m.lockTimeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), m.lockTimeout)
 }
 
-// ListWorkspaces lists all workspaces.
+// ListWorkspaces lists all workspaces. It takes a shared lock so it can run
+// concurrently with other readers but not while a mutating operation (e.g.
+// CreateWorkspace) is in progress.
 func (m *WorkspaceManager) ListWorkspaces(managedOnly bool) ([]Workspace, error) {
-	workspaces, err := DiscoverWorkspaces(m.repoRoot)
+	ctx, cancel := m.lockContext()
+	defer cancel()
+
+	var workspaces []Workspace
+	err := m.WithLock(ctx, LockShared, func() error {
+		var err error
+		workspaces, err = DiscoverWorkspaces(m.repoRoot)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+
 	if managedOnly {
 		var managed []Workspace
 		for _, w := range workspaces {
@@ -60,8 +95,23 @@ func (m *WorkspaceManager) GetWorkspace(branch string) (*Workspace, error) {
 	return workspace, nil
 }
 
-// CreateWorkspace creates a new workspace with worktree.
-func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*Workspace, error) {
+// CreateWorkspace creates a new workspace with worktree. It holds an
+// exclusive lock for the duration of the operation so two concurrent
+// `agentctl workspace create` invocations can't race on directory creation
+// or `git worktree add`. ctx bounds only the wait to acquire the lock; the
+// worktree creation itself does not take ctx and is not cancelled by it.
+// Callers that don't need a deadline can pass context.Background().
+func (m *WorkspaceManager) CreateWorkspace(ctx context.Context, branch string, baseBranch string) (*Workspace, error) {
+	var result *Workspace
+	err := m.WithLock(ctx, LockExclusive, func() error {
+		var err error
+		result, err = m.createWorkspace(branch, baseBranch)
+		return err
+	})
+	return result, err
+}
+
+func (m *WorkspaceManager) createWorkspace(branch string, baseBranch string) (*Workspace, error) {
 	workspacePath, err := GetWorkspacePath(branch, m.repoRoot)
 	if err != nil {
 		return nil, err
@@ -105,6 +155,16 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 		if err := git.AddWorktree(m.repoRoot, workspacePath, branch, true, baseBranch); err != nil {
 			return nil, fmt.Errorf("failed to create worktree: %w", err)
 		}
+		if baseBranch != "HEAD" {
+			if err := SaveWorkspaceMetadata(m.repoRoot, branch, Metadata{BaseBranch: baseBranch}); err != nil {
+				// The worktree was created but its metadata wasn't, so unwind
+				// the worktree rather than leaving a half-created workspace.
+				if rmErr := git.RemoveWorktree(m.repoRoot, workspacePath, true); rmErr != nil {
+					return nil, fmt.Errorf("failed to record base branch: %w (rollback also failed: %v)", err, rmErr)
+				}
+				return nil, fmt.Errorf("failed to record base branch: %w", err)
+			}
+		}
 	}
 
 	// Return the newly created workspace
@@ -118,8 +178,19 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 	return workspace, nil
 }
 
-// DeleteWorkspace removes a workspace.
+// DeleteWorkspace removes a workspace. It holds an exclusive lock for the
+// duration of the operation so CleanWorkspaces or another process can't
+// delete or mutate the same workspace concurrently.
 func (m *WorkspaceManager) DeleteWorkspace(branch string, force bool) error {
+	ctx, cancel := m.lockContext()
+	defer cancel()
+
+	return m.WithLock(ctx, LockExclusive, func() error {
+		return m.deleteWorkspace(branch, force)
+	})
+}
+
+func (m *WorkspaceManager) deleteWorkspace(branch string, force bool) error {
 	workspace, err := m.GetWorkspace(branch)
 	if err != nil {
 		return err
@@ -184,7 +255,20 @@ func (m *WorkspaceManager) CleanWorkspaces(checkMerged bool) ([]string, error) {
 	return removed, nil
 }
 
-// GetWorkspaceStatus gets detailed status information for a workspace.
+// UpstreamStatus reports how a workspace's HEAD compares to a single
+// upstream ref: how many commits each side has added since their merge
+// base.
+type UpstreamStatus struct {
+	Name      string `json:"name"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+	MergeBase string `json:"merge_base,omitempty"`
+}
+
+// GetWorkspaceStatus gets detailed status information for a workspace,
+// including ahead/behind counts against every upstream worth tracking:
+// origin/<branch>, the workspace's configured base branch (if recorded),
+// and main.
 func (m *WorkspaceManager) GetWorkspaceStatus(workspace *Workspace) (map[string]interface{}, error) {
 	isClean, status := git.IsWorktreeClean(workspace.Path)
 
@@ -196,70 +280,200 @@ func (m *WorkspaceManager) GetWorkspaceStatus(workspace *Workspace) (map[string]
 		"status":   status,
 	}
 
-	// Get ahead/behind information
-	if workspace.Branch != "" {
-		repo, err := git.OpenRepo(workspace.Path)
-		if err == nil {
-			head, err := repo.Head()
-			if err == nil {
-				// Get remote tracking branch
-				remoteRefName := plumbing.NewRemoteReferenceName("origin", workspace.Branch)
-				remoteRef, err := repo.Reference(remoteRefName, false)
-				if err == nil {
-					// Calculate ahead/behind
-					ahead, behind, err := calculateAheadBehind(repo, head.Hash(), remoteRef.Hash())
-					if err == nil {
-						result["ahead_behind"] = map[string]int{
-							"ahead":  ahead,
-							"behind": behind,
-						}
-					}
-				}
-			}
+	if workspace.Branch == "" {
+		return result, nil
+	}
+
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return result, nil
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return result, nil
+	}
+
+	var upstreams []UpstreamStatus
+	for _, name := range trackedUpstreamNames(workspace) {
+		ref, err := resolveUpstreamRef(repo, name)
+		if err != nil {
+			continue
 		}
+
+		ahead, behind, mergeBase, err := calculateAheadBehind(repo, head.Hash(), ref.Hash())
+		if err != nil {
+			continue
+		}
+
+		us := UpstreamStatus{Name: name, Ahead: ahead, Behind: behind}
+		if mergeBase != plumbing.ZeroHash {
+			us.MergeBase = mergeBase.String()
+		}
+		upstreams = append(upstreams, us)
+
+		// Preserve the legacy single ahead_behind field for origin/<branch>.
+		if name == "origin/"+workspace.Branch {
+			result["ahead_behind"] = map[string]int{"ahead": ahead, "behind": behind}
+		}
+	}
+
+	if upstreams != nil {
+		result["upstreams"] = upstreams
 	}
 
 	return result, nil
 }
 
-// calculateAheadBehind calculates how many commits ahead and behind local is compared to remote.
-func calculateAheadBehind(repo *git.Repo, localHash, remoteHash plumbing.Hash) (int, int, error) {
-	localCommits, err := getCommitList(repo, localHash, remoteHash)
-	if err != nil {
-		return 0, 0, err
+// trackedUpstreamNames returns the set of upstream refs GetWorkspaceStatus
+// reports against: the remote tracking branch, the workspace's recorded
+// base branch (if any), and main, deduplicated.
+func trackedUpstreamNames(workspace *Workspace) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	add("origin/" + workspace.Branch)
+
+	if workspace.RepoRoot != "" {
+		if meta, err := LoadWorkspaceMetadata(workspace.RepoRoot, workspace.Branch); err == nil && meta.BaseBranch != "" {
+			add(meta.BaseBranch)
+		}
+	}
+
+	add("main")
+	return names
+}
+
+// resolveUpstreamRef resolves an upstream name that may be a remote
+// tracking ref ("origin/foo") or a local branch ("main").
+func resolveUpstreamRef(repo *git.Repo, name string) (*plumbing.Reference, error) {
+	if remote, branch, ok := strings.Cut(name, "/"); ok {
+		if ref, err := repo.Reference(plumbing.NewRemoteReferenceName(remote, branch), true); err == nil {
+			return ref, nil
+		}
+	}
+	return repo.Reference(plumbing.NewBranchReferenceName(name), true)
+}
+
+// calculateAheadBehind computes how far local and remote have diverged using
+// their merge base: commits reachable from local but not from the merge
+// base are "ahead", and commits reachable from remote but not from the
+// merge base are "behind". This gives correct counts when neither is an
+// ancestor of the other. When the two refs share no ancestor, the search
+// walks both histories in full and an error is returned.
+func calculateAheadBehind(repo *git.Repo, localHash, remoteHash plumbing.Hash) (ahead, behind int, mergeBase plumbing.Hash, err error) {
+	if localHash == remoteHash {
+		return 0, 0, localHash, nil
 	}
 
-	remoteCommits, err := getCommitList(repo, remoteHash, localHash)
+	mergeBase, err = findMergeBase(repo, localHash, remoteHash)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, plumbing.ZeroHash, err
 	}
 
-	// Count unique commits
-	localSet := make(map[plumbing.Hash]bool)
-	for _, hash := range localCommits {
-		localSet[hash] = true
+	ahead, err = countCommitsSinceMergeBase(repo, localHash, mergeBase)
+	if err != nil {
+		return 0, 0, plumbing.ZeroHash, err
 	}
 
-	remoteSet := make(map[plumbing.Hash]bool)
-	for _, hash := range remoteCommits {
-		remoteSet[hash] = true
+	behind, err = countCommitsSinceMergeBase(repo, remoteHash, mergeBase)
+	if err != nil {
+		return 0, 0, plumbing.ZeroHash, err
 	}
 
-	ahead := 0
-	for hash := range localSet {
-		if !remoteSet[hash] {
-			ahead++
+	return ahead, behind, mergeBase, nil
+}
+
+// findMergeBase finds a lowest common ancestor of a and b using a two-color
+// breadth-first search: commits reachable from a are colored 1, from b are
+// colored 2, and the search stops as soon as it reaches a commit already
+// visited by the other color.
+func findMergeBase(repo *git.Repo, a, b plumbing.Hash) (plumbing.Hash, error) {
+	visitedA := map[plumbing.Hash]bool{a: true}
+	visitedB := map[plumbing.Hash]bool{b: true}
+	queueA := []plumbing.Hash{a}
+	queueB := []plumbing.Hash{b}
+
+	for len(queueA) > 0 || len(queueB) > 0 {
+		if len(queueA) > 0 {
+			h := queueA[0]
+			queueA = queueA[1:]
+			if visitedB[h] {
+				return h, nil
+			}
+			commit, err := repo.CommitObject(h)
+			if err == nil {
+				for _, parent := range commit.ParentHashes {
+					if !visitedA[parent] {
+						visitedA[parent] = true
+						queueA = append(queueA, parent)
+					}
+				}
+			}
+		}
+
+		if len(queueB) > 0 {
+			h := queueB[0]
+			queueB = queueB[1:]
+			if visitedA[h] {
+				return h, nil
+			}
+			commit, err := repo.CommitObject(h)
+			if err == nil {
+				for _, parent := range commit.ParentHashes {
+					if !visitedB[parent] {
+						visitedB[parent] = true
+						queueB = append(queueB, parent)
+					}
+				}
+			}
 		}
 	}
 
-	behind := 0
-	for hash := range remoteSet {
-		if !localSet[hash] {
-			behind++
+	return plumbing.ZeroHash, fmt.Errorf("no common ancestor found between %s and %s", a, b)
+}
+
+// countCommitsSinceMergeBase counts commits reachable from tip but not from
+// mergeBase, stopping traversal at mergeBase rather than walking past it
+// into shared history.
+func countCommitsSinceMergeBase(repo *git.Repo, tip, mergeBase plumbing.Hash) (int, error) {
+	if tip == mergeBase {
+		return 0, nil
+	}
+
+	visited := map[plumbing.Hash]bool{mergeBase: true, tip: true}
+	queue := []plumbing.Hash{tip}
+	count := 1
+
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+
+		commit, err := repo.CommitObject(h)
+		if err != nil {
+			continue
+		}
+		for _, parent := range commit.ParentHashes {
+			if visited[parent] {
+				continue
+			}
+			visited[parent] = true
+			if parent == mergeBase {
+				continue
+			}
+			count++
+			queue = append(queue, parent)
 		}
 	}
 
-	return ahead, behind, nil
+	return count, nil
 }
 
 // getCommitList gets all commits reachable from fromHash but not from toHash.
@@ -292,7 +506,7 @@ func getCommitList(repo *git.Repo, fromHash, toHash plumbing.Hash) ([]plumbing.H
 
 // GetWorkspaceDiff gets git diff from workspace to target branch.
 func (m *WorkspaceManager) GetWorkspaceDiff(workspace *Workspace, targetBranch string) (string, error) {
-	repo, err := git.OpenRepo(workspace.Path)
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
 	if err != nil {
 		return "", fmt.Errorf("failed to open repository: %w", err)
 	}
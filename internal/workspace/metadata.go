@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Metadata holds per-workspace information that doesn't live in git itself,
+// such as the URL of a pull/merge request opened from the workspace.
+type Metadata struct {
+	PRURL string `json:"pr_url,omitempty"`
+	// BaseBranch is the branch this workspace was created from, used to
+	// report ahead/behind status against it in GetWorkspaceStatus.
+	BaseBranch string `json:"base_branch,omitempty"`
+}
+
+// metadataPath returns the path to the repo-wide workspace metadata file,
+// keyed by branch name.
+func metadataPath(repoRoot string) string {
+	return filepath.Join(repoRoot, ".git", "agentctl", "workspace-meta.json")
+}
+
+// loadAllMetadata reads the metadata file, returning an empty map if it
+// doesn't exist yet.
+func loadAllMetadata(repoRoot string) (map[string]Metadata, error) {
+	data, err := os.ReadFile(metadataPath(repoRoot)) //nolint:gosec // Path is derived from the repo root
+	if os.IsNotExist(err) {
+		return map[string]Metadata{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]Metadata
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// LoadWorkspaceMetadata returns the stored metadata for branch, or a zero
+// Metadata if none has been recorded.
+func LoadWorkspaceMetadata(repoRoot, branch string) (Metadata, error) {
+	all, err := loadAllMetadata(repoRoot)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return all[branch], nil
+}
+
+// SaveWorkspaceMetadata merges meta into the stored metadata for branch.
+func SaveWorkspaceMetadata(repoRoot, branch string, meta Metadata) error {
+	all, err := loadAllMetadata(repoRoot)
+	if err != nil {
+		return err
+	}
+	existing := all[branch]
+	if meta.PRURL != "" {
+		existing.PRURL = meta.PRURL
+	}
+	if meta.BaseBranch != "" {
+		existing.BaseBranch = meta.BaseBranch
+	}
+	all[branch] = existing
+
+	path := metadataPath(repoRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // Directory needs to be readable
+		return err
+	}
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644) //nolint:gosec // Metadata file needs to be readable
+}
@@ -0,0 +1,170 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/remote"
+	"github.com/ryantking/agentctl/internal/templates"
+)
+
+// PROptions controls OpenWorkspacePR's behavior.
+type PROptions struct {
+	Base     string // defaults to "main"
+	Title    string // auto-generated from the commit range if empty
+	Body     string // auto-generated from the commit range if empty
+	Draft    bool
+	Provider string // explicit provider name ("github"/"gitlab"); empty auto-detects
+}
+
+// OpenWorkspacePR pushes a workspace's branch and opens a pull/merge request
+// against opts.Base, auto-generating title/body from the commit range when
+// not supplied. The resulting PR URL is recorded in the workspace's
+// metadata so `workspace list` can display it.
+func (m *WorkspaceManager) OpenWorkspacePR(ctx context.Context, branch string, opts PROptions) (*remote.PR, error) {
+	workspace, err := m.GetWorkspace(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if isClean, status := workspace.IsClean(); !isClean {
+		return nil, fmt.Errorf("workspace has uncommitted changes (%s); commit or stash first", status)
+	}
+
+	base := opts.Base
+	if base == "" {
+		base = "main"
+	}
+
+	if err := git.Push(workspace.Path, "origin", branch); err != nil {
+		return nil, err
+	}
+
+	var provider remote.Provider
+	if opts.Provider != "" {
+		provider, err = remote.ProviderByName(opts.Provider, m.repoRoot)
+	} else {
+		provider, err = remote.DetectProvider(m.repoRoot)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	title, body, err := renderPRContent(workspace.Path, branch, base, opts.Title, opts.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := provider.OpenPullRequest(ctx, m.repoRoot, remote.PROptions{
+		Branch: branch,
+		Base:   base,
+		Title:  title,
+		Body:   body,
+		Draft:  opts.Draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SaveWorkspaceMetadata(m.repoRoot, branch, Metadata{PRURL: pr.URL}); err != nil {
+		return nil, fmt.Errorf("PR opened (%s) but failed to save workspace metadata: %w", pr.URL, err)
+	}
+
+	return pr, nil
+}
+
+// prTemplateData is the data passed to the pr/title.tmpl and pr/body.tmpl
+// templates.
+type prTemplateData struct {
+	Branch        string
+	Base          string
+	FirstSubject  string
+	CommitSummary string
+}
+
+// renderPRContent fills in any of title/body left empty by the caller using
+// the commit range between base and branch, rendered through the pr
+// templates.
+func renderPRContent(workspacePath, branch, base, title, body string) (string, string, error) {
+	if title != "" && body != "" {
+		return title, body, nil
+	}
+
+	data, err := commitRangeData(workspacePath, branch, base)
+	if err != nil {
+		return "", "", err
+	}
+
+	if title == "" {
+		rendered, err := templates.Render("pr/title.tmpl", data)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to render PR title template: %w", err)
+		}
+		title = strings.TrimSpace(rendered)
+	}
+	if body == "" {
+		rendered, err := templates.Render("pr/body.tmpl", data)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to render PR body template: %w", err)
+		}
+		body = rendered
+	}
+	return title, body, nil
+}
+
+// commitRangeData summarizes the commits reachable from branch but not from
+// base: the first commit's subject line and a `git log --oneline`-style
+// summary of the whole range.
+func commitRangeData(workspacePath, branch, base string) (prTemplateData, error) {
+	repo, err := git.OpenRepo(context.Background(), workspacePath)
+	if err != nil {
+		return prTemplateData{}, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	branchRef, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return prTemplateData{}, fmt.Errorf("failed to resolve branch %s: %w", branch, err)
+	}
+
+	baseRef, err := repo.Reference(plumbing.NewBranchReferenceName(base), true)
+	if err != nil {
+		return prTemplateData{}, fmt.Errorf("failed to resolve base %s: %w", base, err)
+	}
+
+	mergeBase, err := findMergeBase(repo, branchRef.Hash(), baseRef.Hash())
+	if err != nil {
+		return prTemplateData{}, fmt.Errorf("failed to compute commit range: %w", err)
+	}
+	hashes, err := getCommitList(repo, branchRef.Hash(), mergeBase)
+	if err != nil {
+		return prTemplateData{}, fmt.Errorf("failed to compute commit range: %w", err)
+	}
+
+	data := prTemplateData{Branch: branch, Base: base}
+	if len(hashes) == 0 {
+		data.FirstSubject = branch
+		data.CommitSummary = "(no commits ahead of " + base + ")"
+		return data, nil
+	}
+
+	var lines []string
+	for i, hash := range hashes {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			continue
+		}
+		subject := strings.SplitN(commit.Message, "\n", 2)[0]
+		lines = append(lines, fmt.Sprintf("- %s %s", hash.String()[:8], subject))
+		if i == len(hashes)-1 {
+			// getCommitList walks newest-first; the oldest commit's subject
+			// reads best as the PR title.
+			data.FirstSubject = subject
+		}
+	}
+
+	data.CommitSummary = strings.Join(lines, "\n")
+	return data, nil
+}
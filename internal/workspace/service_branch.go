@@ -0,0 +1,413 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	billy "github.com/go-git/go-billy/v5"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// ServiceBranchPrefix namespaces the shadow refs used for workspace
+// snapshots, kept outside refs/heads so they never show up as checkout
+// candidates.
+const ServiceBranchPrefix = "refs/agentctl/service/"
+
+// Snapshot describes a single commit written to a workspace's service
+// branch.
+type Snapshot struct {
+	ID      string `json:"id"` // short commit hash
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+}
+
+// SnapshotOptions controls SnapshotWorkspace's behavior.
+type SnapshotOptions struct {
+	// ExcludeGlobs are additional gitignore-style patterns, matched on top
+	// of whatever the workspace's own .gitignore already excludes.
+	ExcludeGlobs []string
+	// Push pushes the resulting service-branch ref to origin after
+	// committing.
+	Push bool
+}
+
+// serviceBranchRefName returns the shadow ref name for a workspace branch.
+func serviceBranchRefName(branch string) plumbing.ReferenceName {
+	return plumbing.ReferenceName(ServiceBranchPrefix + branch)
+}
+
+// SnapshotWorkspace builds a tree mirroring the workspace's current
+// filesystem state — including uncommitted and untracked files, subject to
+// .gitignore and opts.ExcludeGlobs — and commits it to the workspace's
+// service branch ref, parented on the ref's previous tip (or the workspace's
+// HEAD for the first snapshot). This never touches the checked-out branch or
+// the index.
+func (m *WorkspaceManager) SnapshotWorkspace(branch string, opts SnapshotOptions) (*Snapshot, error) {
+	workspace, err := m.GetWorkspace(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	matcher, err := buildIgnoreMatcher(worktree.Filesystem, opts.ExcludeGlobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ignore matcher: %w", err)
+	}
+
+	treeHash, err := writeTreeFromFilesystem(repo.Storer, worktree.Filesystem, "", matcher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot worktree: %w", err)
+	}
+
+	refName := serviceBranchRefName(branch)
+	parents := []plumbing.Hash{head.Hash()}
+	if existing, err := repo.Reference(refName, true); err == nil {
+		parents = []plumbing.Hash{existing.Hash()}
+	}
+
+	sig := object.Signature{Name: "agentctl", Email: "agentctl@localhost", When: time.Now()}
+	message := fmt.Sprintf("snapshot: %s", branch)
+	commit := &object.Commit{
+		Author:       sig,
+		Committer:    sig,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.CommitObject)
+	if err := commit.Encode(obj); err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot commit: %w", err)
+	}
+	commitHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write snapshot commit: %w", err)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(refName, commitHash)); err != nil {
+		return nil, fmt.Errorf("failed to update service branch ref: %w", err)
+	}
+
+	if opts.Push {
+		refSpec := config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))
+		err := repo.Push(&gogit.PushOptions{RemoteName: "origin", RefSpecs: []config.RefSpec{refSpec}})
+		if err != nil && err != gogit.NoErrAlreadyUpToDate {
+			return nil, fmt.Errorf("failed to push service branch: %w", err)
+		}
+	}
+
+	return &Snapshot{ID: commitHash.String()[:8], Ref: refName.String(), Message: message}, nil
+}
+
+// RestoreWorkspace checks out the tree recorded in a service-branch
+// snapshot into the workspace's working directory without moving the
+// checked-out branch or creating a commit on it. An empty snapshotID
+// restores the most recent snapshot.
+func (m *WorkspaceManager) RestoreWorkspace(branch, snapshotID string) error {
+	workspace, err := m.GetWorkspace(branch)
+	if err != nil {
+		return err
+	}
+
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	refName := serviceBranchRefName(branch)
+	ref, err := repo.Reference(refName, true)
+	if err != nil {
+		return fmt.Errorf("no snapshots found for %s: %w", branch, err)
+	}
+
+	commitHash := ref.Hash()
+	if snapshotID != "" {
+		resolved, err := resolveSnapshotAncestor(repo, ref.Hash(), snapshotID)
+		if err != nil {
+			return err
+		}
+		commitHash = resolved
+	}
+
+	commit, err := repo.CommitObject(commitHash)
+	if err != nil {
+		return fmt.Errorf("snapshot %s not found: %w", snapshotID, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	kept := make(map[string]bool)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		kept[f.Name] = true
+		contents, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		return writeWorktreeFile(worktree, f.Name, contents)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Writing the snapshot's files back only overlays them; a file the
+	// workspace has now but the snapshot didn't (because it was created, or
+	// deleted, after that snapshot) would otherwise survive the "restore",
+	// contradicting the whole point of time-traveling a workspace. Remove
+	// anything not in the snapshot, skipping whatever the same ignore
+	// matching SnapshotWorkspace uses already excludes from snapshots, since
+	// those were never the snapshot's concern to begin with.
+	matcher, err := buildIgnoreMatcher(worktree.Filesystem, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build ignore matcher: %w", err)
+	}
+	return removeFilesNotIn(worktree.Filesystem, "", kept, matcher)
+}
+
+// removeFilesNotIn walks fs from dir, removing any file whose path (relative
+// to the worktree root, joined the same way writeTreeFromFilesystem builds
+// tree entries) isn't in kept. The `.git` directory and anything matcher
+// excludes are left untouched, mirroring what SnapshotWorkspace itself never
+// captures.
+func removeFilesNotIn(fs billy.Filesystem, dir string, kept map[string]bool, matcher gitignore.Matcher) error {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if dir == "" && name == ".git" {
+			continue
+		}
+		relPath := filepath.Join(dir, name)
+		pathParts := strings.Split(relPath, string(filepath.Separator))
+		if matcher.Match(pathParts, entry.IsDir()) {
+			continue
+		}
+
+		if entry.IsDir() {
+			if err := removeFilesNotIn(fs, relPath, kept, matcher); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !kept[relPath] {
+			if err := fs.Remove(relPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PromoteServiceBranch squash-merges a workspace's accumulated service
+// branch snapshots onto targetBranch as a single commit, carrying over the
+// service branch's current tree wholesale. The service branch ref itself is
+// left in place, so a later SnapshotWorkspace keeps parenting off it as
+// usual. If targetBranch is empty, defaults to branch.
+func (m *WorkspaceManager) PromoteServiceBranch(branch, targetBranch string) (*Snapshot, error) {
+	workspace, err := m.GetWorkspace(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetBranch == "" {
+		targetBranch = branch
+	}
+
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	serviceRef, err := repo.Reference(serviceBranchRefName(branch), true)
+	if err != nil {
+		return nil, fmt.Errorf("no snapshots found for %s: %w", branch, err)
+	}
+
+	serviceCommit, err := repo.CommitObject(serviceRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve service branch commit: %w", err)
+	}
+
+	targetRefName := plumbing.NewBranchReferenceName(targetBranch)
+	targetRef, err := repo.Reference(targetRefName, true)
+	if err != nil {
+		return nil, fmt.Errorf("target branch %s not found: %w", targetBranch, err)
+	}
+
+	sig := object.Signature{Name: "agentctl", Email: "agentctl@localhost", When: time.Now()}
+	message := fmt.Sprintf("Promote service branch snapshots for %s", branch)
+	commit := &object.Commit{
+		Author:       sig,
+		Committer:    sig,
+		Message:      message,
+		TreeHash:     serviceCommit.TreeHash,
+		ParentHashes: []plumbing.Hash{targetRef.Hash()},
+	}
+
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.CommitObject)
+	if err := commit.Encode(obj); err != nil {
+		return nil, fmt.Errorf("failed to encode promotion commit: %w", err)
+	}
+	commitHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write promotion commit: %w", err)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(targetRefName, commitHash)); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", targetBranch, err)
+	}
+
+	if branch == targetBranch {
+		worktree, err := repo.Worktree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get worktree: %w", err)
+		}
+		if err := worktree.Checkout(&gogit.CheckoutOptions{Branch: targetRefName, Force: true}); err != nil {
+			return nil, fmt.Errorf("failed to update worktree after promotion: %w", err)
+		}
+	}
+
+	return &Snapshot{ID: commitHash.String()[:8], Ref: targetRefName.String(), Message: message}, nil
+}
+
+// resolveSnapshotAncestor walks the service branch's commit chain looking
+// for a commit whose short hash matches snapshotID.
+func resolveSnapshotAncestor(repo *git.Repo, tip plumbing.Hash, snapshotID string) (plumbing.Hash, error) {
+	hash := tip
+	for {
+		if strings.HasPrefix(hash.String(), snapshotID) {
+			return hash, nil
+		}
+		commit, err := repo.CommitObject(hash)
+		if err != nil || len(commit.ParentHashes) == 0 {
+			return plumbing.ZeroHash, fmt.Errorf("snapshot %s not found in service branch history", snapshotID)
+		}
+		hash = commit.ParentHashes[0]
+	}
+}
+
+// buildIgnoreMatcher loads .gitignore patterns from the worktree plus any
+// user-supplied exclude patterns.
+func buildIgnoreMatcher(fs billy.Filesystem, excludePatterns []string) (gitignore.Matcher, error) {
+	patterns, err := gitignore.ReadPatterns(fs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range excludePatterns {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+	return gitignore.NewMatcher(patterns), nil
+}
+
+// writeTreeFromFilesystem recursively walks dir (relative to the worktree
+// root) via go-billy, writes a blob object per file and a tree object per
+// directory into storer, and returns the hash of the tree for dir. Paths
+// matched by matcher (gitignore + exclude patterns) and the `.git` directory
+// itself are skipped.
+func writeTreeFromFilesystem(storer storage.Storer, fs billy.Filesystem, dir string, matcher gitignore.Matcher) (plumbing.Hash, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var treeEntries []object.TreeEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		if dir == "" && name == ".git" {
+			continue
+		}
+		relPath := filepath.Join(dir, name)
+		pathParts := strings.Split(relPath, string(filepath.Separator))
+		if matcher.Match(pathParts, entry.IsDir()) {
+			continue
+		}
+
+		if entry.IsDir() {
+			hash, err := writeTreeFromFilesystem(storer, fs, relPath, matcher)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			treeEntries = append(treeEntries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: hash})
+			continue
+		}
+
+		hash, err := writeBlobFromFile(storer, fs, relPath)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		treeEntries = append(treeEntries, object.TreeEntry{Name: name, Mode: filemode.Regular, Hash: hash})
+	}
+
+	tree := &object.Tree{Entries: treeEntries}
+	obj := storer.NewEncodedObject()
+	obj.SetType(plumbing.TreeObject)
+	if err := tree.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return storer.SetEncodedObject(obj)
+}
+
+func writeBlobFromFile(storer storage.Storer, fs billy.Filesystem, relPath string) (plumbing.Hash, error) {
+	file, err := fs.Open(relPath)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer func() { _ = file.Close() }()
+
+	obj := storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	writer, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := io.Copy(writer, file); err != nil {
+		_ = writer.Close()
+		return plumbing.ZeroHash, err
+	}
+	if err := writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return storer.SetEncodedObject(obj)
+}
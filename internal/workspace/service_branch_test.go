@@ -0,0 +1,392 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// initTestRepo creates a repository at t.TempDir() with a single commit on
+// "main" and returns its root, along with a WorkspaceManager rooted there.
+func initTestRepo(t *testing.T) (string, *WorkspaceManager) {
+	t.Helper()
+
+	root := t.TempDir()
+	repo, err := gogit.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("hello\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	commit, err := wt.Commit("initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), commit)); err != nil {
+		t.Fatalf("failed to set main ref: %v", err)
+	}
+	head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))
+	if err := repo.Storer.SetReference(head); err != nil {
+		t.Fatalf("failed to set HEAD: %v", err)
+	}
+
+	manager, err := NewManagerAt(root)
+	if err != nil {
+		t.Fatalf("NewManagerAt failed: %v", err)
+	}
+	return root, manager
+}
+
+func TestSnapshotWorkspaceCapturesUncommittedChanges(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "untracked.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatalf("failed to write untracked.txt: %v", err)
+	}
+
+	snapshot, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+	if snapshot.Ref != serviceBranchRefName("main").String() {
+		t.Errorf("expected ref %s, got %s", serviceBranchRefName("main"), snapshot.Ref)
+	}
+
+	repo, err := gogit.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	ref, err := repo.Reference(serviceBranchRefName("main"), true)
+	if err != nil {
+		t.Fatalf("expected service branch ref to exist: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+
+	readmeFile, err := tree.File("README.md")
+	if err != nil {
+		t.Fatalf("expected README.md in snapshot tree: %v", err)
+	}
+	contents, err := readmeFile.Contents()
+	if err != nil {
+		t.Fatalf("Contents failed: %v", err)
+	}
+	if contents != "changed\n" {
+		t.Errorf("expected snapshot to capture the uncommitted README change, got %q", contents)
+	}
+
+	if _, err := tree.File("untracked.txt"); err != nil {
+		t.Errorf("expected snapshot to capture the untracked file: %v", err)
+	}
+
+	// HEAD itself must be untouched: the branch still points at the original commit.
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	if head.Hash() == ref.Hash() {
+		t.Errorf("expected HEAD to remain separate from the service branch snapshot")
+	}
+}
+
+func TestSnapshotWorkspaceRespectsGitignore(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("ignored.txt\n"), 0644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "ignored.txt"), []byte("skip me\n"), 0644); err != nil {
+		t.Fatalf("failed to write ignored.txt: %v", err)
+	}
+
+	snapshot, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	repo, err := gogit.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(snapshot.Ref), true)
+	if err != nil {
+		t.Fatalf("Reference failed: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+
+	if _, err := tree.File("ignored.txt"); err == nil {
+		t.Errorf("expected ignored.txt to be excluded from the snapshot")
+	}
+}
+
+func TestSnapshotWorkspaceExcludeGlobs(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, "secrets.env"), []byte("TOKEN=x\n"), 0644); err != nil {
+		t.Fatalf("failed to write secrets.env: %v", err)
+	}
+
+	snapshot, err := manager.SnapshotWorkspace("main", SnapshotOptions{ExcludeGlobs: []string{"*.env"}})
+	if err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	repo, err := gogit.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(snapshot.Ref), true)
+	if err != nil {
+		t.Fatalf("Reference failed: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+
+	if _, err := tree.File("secrets.env"); err == nil {
+		t.Errorf("expected secrets.env to be excluded by ExcludeGlobs")
+	}
+}
+
+func TestSnapshotWorkspaceParentsOnPreviousSnapshot(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	first, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("first SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("v2\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	second, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("second SnapshotWorkspace failed: %v", err)
+	}
+
+	repo, err := gogit.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(second.Ref), true)
+	if err != nil {
+		t.Fatalf("Reference failed: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	if len(commit.ParentHashes) != 1 || commit.ParentHashes[0].String()[:8] != first.ID {
+		t.Errorf("expected second snapshot to parent on the first (%s), got parents %v", first.ID, commit.ParentHashes)
+	}
+}
+
+func TestRestoreWorkspaceWritesSnapshotFiles(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("snapshotted\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "new.txt"), []byte("new content\n"), 0644); err != nil {
+		t.Fatalf("failed to write new.txt: %v", err)
+	}
+	if _, err := manager.SnapshotWorkspace("main", SnapshotOptions{}); err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	// Mutate the working directory after the snapshot, then restore it.
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("overwritten\n"), 0644); err != nil {
+		t.Fatalf("failed to overwrite README: %v", err)
+	}
+	if err := os.Remove(filepath.Join(root, "new.txt")); err != nil {
+		t.Fatalf("failed to remove new.txt: %v", err)
+	}
+
+	if err := manager.RestoreWorkspace("main", ""); err != nil {
+		t.Fatalf("RestoreWorkspace failed: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README.md: %v", err)
+	}
+	if string(readme) != "snapshotted\n" {
+		t.Errorf("expected README.md to be restored to 'snapshotted', got %q", readme)
+	}
+
+	newFile, err := os.ReadFile(filepath.Join(root, "new.txt"))
+	if err != nil {
+		t.Fatalf("expected new.txt to be restored: %v", err)
+	}
+	if string(newFile) != "new content\n" {
+		t.Errorf("expected new.txt contents to be restored, got %q", newFile)
+	}
+}
+
+func TestRestoreWorkspaceBySpecificSnapshotID(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("v1\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	first, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("first SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("v2\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	if _, err := manager.SnapshotWorkspace("main", SnapshotOptions{}); err != nil {
+		t.Fatalf("second SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := manager.RestoreWorkspace("main", first.ID); err != nil {
+		t.Fatalf("RestoreWorkspace(first.ID) failed: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README.md: %v", err)
+	}
+	if string(readme) != "v1\n" {
+		t.Errorf("expected README.md restored from the first snapshot ('v1'), got %q", readme)
+	}
+}
+
+func TestRestoreWorkspaceRemovesFilesAddedAfterSnapshot(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	first, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("first SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "new.txt"), []byte("added later\n"), 0644); err != nil {
+		t.Fatalf("failed to write new.txt: %v", err)
+	}
+	if _, err := manager.SnapshotWorkspace("main", SnapshotOptions{}); err != nil {
+		t.Fatalf("second SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := manager.RestoreWorkspace("main", first.ID); err != nil {
+		t.Fatalf("RestoreWorkspace(first.ID) failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected new.txt to be removed when restoring to a snapshot that predates it, got err=%v", err)
+	}
+}
+
+func TestRestoreWorkspaceLeavesGitignoredFilesAlone(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("ignored.txt\n"), 0644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+	snapshot, err := manager.SnapshotWorkspace("main", SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "ignored.txt"), []byte("local only\n"), 0644); err != nil {
+		t.Fatalf("failed to write ignored.txt: %v", err)
+	}
+
+	if err := manager.RestoreWorkspace("main", snapshot.ID); err != nil {
+		t.Fatalf("RestoreWorkspace failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "ignored.txt")); err != nil {
+		t.Errorf("expected gitignored file to survive restore untouched, got err=%v", err)
+	}
+}
+
+func TestRestoreWorkspaceNoSnapshotsReturnsError(t *testing.T) {
+	_, manager := initTestRepo(t)
+
+	if err := manager.RestoreWorkspace("main", ""); err == nil {
+		t.Error("expected an error restoring a workspace with no snapshots")
+	}
+}
+
+func TestPromoteServiceBranchSquashesOntoTarget(t *testing.T) {
+	root, manager := initTestRepo(t)
+
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("promoted\n"), 0644); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+	if _, err := manager.SnapshotWorkspace("main", SnapshotOptions{}); err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	promoted, err := manager.PromoteServiceBranch("main", "")
+	if err != nil {
+		t.Fatalf("PromoteServiceBranch failed: %v", err)
+	}
+	if promoted.Ref != plumbing.NewBranchReferenceName("main").String() {
+		t.Errorf("expected promotion onto refs/heads/main, got %s", promoted.Ref)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(root, "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README.md: %v", err)
+	}
+	if string(readme) != "promoted\n" {
+		t.Errorf("expected the worktree to be updated to the promoted tree, got %q", readme)
+	}
+
+	repo, err := gogit.PlainOpen(root)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	if len(commit.ParentHashes) != 1 {
+		t.Errorf("expected a single-parent squash commit, got %d parents", len(commit.ParentHashes))
+	}
+}
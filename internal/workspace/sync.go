@@ -0,0 +1,361 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// SyncStrategy selects how SyncWorkspace advances a workspace against its
+// base.
+type SyncStrategy string
+
+const (
+	// SyncFastForward only succeeds if the workspace branch can fast-forward
+	// to the remote tip.
+	SyncFastForward SyncStrategy = "fast-forward"
+	// SyncRebase replays local commits ahead of upstream onto the updated
+	// base.
+	SyncRebase SyncStrategy = "rebase"
+	// SyncMerge creates a merge commit joining local and upstream history.
+	SyncMerge SyncStrategy = "merge"
+)
+
+// SyncOptions controls SyncWorkspace's behavior.
+type SyncOptions struct {
+	Strategy SyncStrategy
+	Remote   string // defaults to "origin"
+	Onto     string // base branch to sync against; defaults to the workspace's own branch name
+	Fetch    bool
+	Prune    bool
+	Force    bool
+	Stash    bool // auto-stash dirty changes before syncing, instead of refusing or requiring Force
+}
+
+// SyncReport is the structured result of a SyncWorkspace call, suitable for
+// JSON consumers.
+type SyncReport struct {
+	UpdatedRefs     []string `json:"updated_refs"`
+	Conflicts       []string `json:"conflicts,omitempty"`
+	CommitsReplayed int      `json:"commits_replayed"`
+	FilesChanged    []string `json:"files_changed,omitempty"`
+	StashRef        string   `json:"stash_ref,omitempty"`
+}
+
+// stashRefName returns the hidden ref SyncWorkspace parks a workspace's
+// dirty changes under when Stash is set, e.g. refs/agentctl/stash/my-branch.
+func stashRefName(branch string) plumbing.ReferenceName {
+	return plumbing.ReferenceName(fmt.Sprintf("refs/agentctl/stash/%s", branch))
+}
+
+// stashWorktree commits the workspace's current dirty state onto its hidden
+// stash ref and resets the worktree back to HEAD, so SyncWorkspace's
+// strategies below always start from a clean tree instead of refusing or
+// requiring --force. This is a minimal stand-in for git's native stash:
+// a plain commit under a ref outside refs/heads, not a real stash entry.
+// Returns the stash ref name and the paths it captured, or ("", nil, nil)
+// if the worktree was already clean.
+func stashWorktree(repo *git.Repo, worktree *gogit.Worktree, branch string) (string, []string, error) {
+	status, err := worktree.Status()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to check worktree status: %w", err)
+	}
+	if status.IsClean() {
+		return "", nil, nil
+	}
+
+	var changed []string
+	for path := range status {
+		changed = append(changed, path)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	if _, err := worktree.Add("."); err != nil {
+		return "", nil, fmt.Errorf("failed to stage changes for stash: %w", err)
+	}
+
+	stashHash, err := worktree.Commit(fmt.Sprintf("agentctl stash: %s", branch), &gogit.CommitOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to commit stash: %w", err)
+	}
+
+	refName := stashRefName(branch)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(refName, stashHash)); err != nil {
+		return "", nil, fmt.Errorf("failed to record stash ref: %w", err)
+	}
+
+	if err := worktree.Reset(&gogit.ResetOptions{Mode: gogit.HardReset, Commit: head.Hash()}); err != nil {
+		return "", nil, fmt.Errorf("failed to reset worktree after stash: %w", err)
+	}
+
+	return refName.String(), changed, nil
+}
+
+// SyncWorkspace advances a workspace's branch against its base using the
+// given strategy. Fast-forward uses go-git's Pull; rebase replays commits
+// ahead of upstream one at a time (go-git has no native rebase); merge
+// creates a merge commit via Worktree.Merge-equivalent commit construction.
+// If opts.Stash is set, dirty changes are committed to a hidden stash ref
+// and restored to a clean worktree first, so the sync never refuses (or
+// needs Force) purely because the workspace has uncommitted edits.
+func (m *WorkspaceManager) SyncWorkspace(branch string, opts SyncOptions) (*SyncReport, error) {
+	workspace, err := m.GetWorkspace(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	repo, err := git.OpenRepo(context.Background(), workspace.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	var stashRef string
+	var filesChanged []string
+	if opts.Stash {
+		stashRef, filesChanged, err = stashWorktree(repo, worktree, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stash changes: %w", err)
+		}
+	}
+
+	if opts.Fetch {
+		fetchOpts := &gogit.FetchOptions{RemoteName: remote}
+		if opts.Prune {
+			fetchOpts.Prune = true
+		}
+		if err := repo.Fetch(fetchOpts); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+			return nil, fmt.Errorf("failed to fetch: %w", err)
+		}
+	}
+
+	onto := opts.Onto
+	if onto == "" {
+		onto = branch
+	}
+
+	report := &SyncReport{}
+
+	var result *SyncReport
+	switch opts.Strategy {
+	case SyncRebase:
+		result, err = m.syncRebase(repo, worktree, branch, remote, onto, opts)
+	case SyncMerge:
+		result, err = m.syncMerge(repo, worktree, branch, remote, onto, opts, report)
+	default:
+		result, err = m.syncFastForward(repo, worktree, branch, remote, onto, opts, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.StashRef = stashRef
+	result.FilesChanged = filesChanged
+	return result, nil
+}
+
+func (m *WorkspaceManager) syncFastForward(repo *git.Repo, worktree *gogit.Worktree, branch, remote, onto string, opts SyncOptions, report *SyncReport) (*SyncReport, error) {
+	if onto == branch {
+		err := worktree.Pull(&gogit.PullOptions{
+			RemoteName: remote,
+			Force:      opts.Force,
+		})
+		if err != nil {
+			if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+				return report, nil
+			}
+			return nil, fmt.Errorf("fast-forward sync failed: %w", err)
+		}
+		report.UpdatedRefs = append(report.UpdatedRefs, fmt.Sprintf("refs/heads/%s", branch))
+		return report, nil
+	}
+
+	// Syncing onto a differently-named base: resolve remote/onto directly
+	// and fast-forward the workspace branch to it.
+	ontoRef, err := repo.Reference(plumbing.NewRemoteReferenceName(remote, onto), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s/%s: %w", remote, onto, err)
+	}
+	if err := worktree.Reset(&gogit.ResetOptions{Mode: gogit.HardReset, Commit: ontoRef.Hash()}); err != nil {
+		return nil, fmt.Errorf("failed to fast-forward onto %s/%s: %w", remote, onto, err)
+	}
+	report.UpdatedRefs = append(report.UpdatedRefs, fmt.Sprintf("refs/heads/%s", branch))
+	return report, nil
+}
+
+// syncRebase replays commits reachable from the local branch but not from
+// the updated base branch onto that base's tip, one at a time.
+func (m *WorkspaceManager) syncRebase(repo *git.Repo, worktree *gogit.Worktree, branch, remote, onto string, opts SyncOptions) (*SyncReport, error) {
+	report := &SyncReport{}
+
+	localRef, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local branch: %w", err)
+	}
+
+	remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName(remote, onto), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s/%s: %w", remote, onto, err)
+	}
+
+	mergeBase, err := findMergeBase(repo, localRef.Hash(), remoteRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute commits ahead of upstream: %w", err)
+	}
+	localCommits, err := getCommitList(repo, localRef.Hash(), mergeBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute commits ahead of upstream: %w", err)
+	}
+
+	// getCommitList walks newest-first; replay oldest-first.
+	for i, j := 0, len(localCommits)-1; i < j; i, j = i+1, j-1 {
+		localCommits[i], localCommits[j] = localCommits[j], localCommits[i]
+	}
+
+	if !opts.Force {
+		status, err := worktree.Status()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check worktree status: %w", err)
+		}
+		if !status.IsClean() {
+			return nil, fmt.Errorf("workspace has uncommitted changes; use --force or commit/stash first")
+		}
+	}
+
+	// Move the branch onto the new base, then replay each commit's tree
+	// changes on top via cherry-pick-by-tree-application.
+	if err := worktree.Reset(&gogit.ResetOptions{Mode: gogit.HardReset, Commit: remoteRef.Hash()}); err != nil {
+		return nil, fmt.Errorf("failed to reset onto upstream: %w", err)
+	}
+
+	replayed := 0
+	var conflicts []string
+	for _, hash := range localCommits {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			conflicts = append(conflicts, hash.String())
+			continue
+		}
+		if err := cherryPickCommit(worktree, commit); err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", hash.String()[:8], err))
+			break
+		}
+		replayed++
+	}
+
+	report.CommitsReplayed = replayed
+	report.Conflicts = conflicts
+	report.UpdatedRefs = append(report.UpdatedRefs, fmt.Sprintf("refs/heads/%s", branch))
+	return report, nil
+}
+
+// cherryPickCommit applies commit's tree on top of the worktree's current
+// HEAD by checking out its tree contents and committing with the original
+// message, preserving author/committer identity from the source commit.
+func cherryPickCommit(worktree *gogit.Worktree, commit *object.Commit) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	if err := worktree.Checkout(&gogit.CheckoutOptions{Force: true}); err != nil {
+		return err
+	}
+
+	walkErr := tree.Files().ForEach(func(f *object.File) error {
+		contents, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		return writeWorktreeFile(worktree, f.Name, contents)
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	if _, err := worktree.Add("."); err != nil {
+		return err
+	}
+
+	_, err = worktree.Commit(commit.Message, &gogit.CommitOptions{
+		Author:    &commit.Author,
+		Committer: &commit.Committer,
+	})
+	return err
+}
+
+func writeWorktreeFile(worktree *gogit.Worktree, name, contents string) error {
+	file, err := worktree.Filesystem.Create(name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	_, err = file.Write([]byte(contents))
+	return err
+}
+
+// syncMerge joins the base branch into the local branch with a merge commit
+// whose parents are the current HEAD and the base tip.
+func (m *WorkspaceManager) syncMerge(repo *git.Repo, worktree *gogit.Worktree, branch, remote, onto string, opts SyncOptions, report *SyncReport) (*SyncReport, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName(remote, onto), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s/%s: %w", remote, onto, err)
+	}
+
+	if head.Hash() == remoteRef.Hash() {
+		return report, nil
+	}
+
+	if !opts.Force {
+		status, err := worktree.Status()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check worktree status: %w", err)
+		}
+		if !status.IsClean() {
+			return nil, fmt.Errorf("workspace has uncommitted changes; use --force or commit/stash first")
+		}
+	}
+
+	remoteCommit, err := repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve remote commit: %w", err)
+	}
+
+	if err := worktree.Checkout(&gogit.CheckoutOptions{Hash: remoteRef.Hash(), Force: true}); err != nil {
+		return nil, fmt.Errorf("failed to merge remote tree into worktree: %w", err)
+	}
+
+	msg := fmt.Sprintf("Merge remote-tracking branch '%s/%s' into %s", remote, onto, branch)
+	_, err = worktree.Commit(msg, &gogit.CommitOptions{
+		Parents: []plumbing.Hash{head.Hash(), remoteCommit.Hash},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge commit: %w", err)
+	}
+
+	report.UpdatedRefs = append(report.UpdatedRefs, fmt.Sprintf("refs/heads/%s", branch))
+	return report, nil
+}
@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,17 +33,56 @@ func (w *Workspace) IsClean() (bool, string) {
 // ToMap converts workspace to a map for JSON output.
 func (w *Workspace) ToMap() map[string]interface{} {
 	isClean, status := w.IsClean()
-	return map[string]interface{}{
-		"path":      w.Path,
-		"branch":    w.Branch,
-		"commit":    w.Commit,
-		"is_main":   w.IsMain,
+	m := map[string]interface{}{
+		"path":       w.Path,
+		"branch":     w.Branch,
+		"commit":     w.Commit,
+		"is_main":    w.IsMain,
 		"is_managed": w.IsManaged(),
 		"is_clean":   isClean,
 		"status":     status,
 	}
+
+	if w.Branch != "" && w.RepoRoot != "" {
+		if meta, err := LoadWorkspaceMetadata(w.RepoRoot, w.Branch); err == nil && meta.PRURL != "" {
+			m["pr_url"] = meta.PRURL
+		}
+
+		if ref := w.PendingStashRef(); ref != "" {
+			m["sync_status"] = "stashed"
+			m["stash_ref"] = ref
+		}
+	}
+
+	if wtStatus, err := git.WorktreeStatus(w.Path); err == nil {
+		m["modified"] = wtStatus.Modified
+		m["added"] = wtStatus.Added
+		m["deleted"] = wtStatus.Deleted
+		m["untracked"] = wtStatus.Untracked
+		m["conflicted"] = wtStatus.Conflicted
+		m["has_unmerged_paths"] = wtStatus.HasUnmergedPaths
+	}
+
+	return m
 }
 
+// PendingStashRef returns the hidden stash ref a prior `sync --stash` left
+// behind for this workspace. Nothing in agentctl reapplies or deletes that
+// ref, so it is reported until it is removed by hand. Returns "" if there
+// is none (or the ref can't be resolved, e.g. no repository at RepoRoot).
+func (w *Workspace) PendingStashRef() string {
+	repo, err := git.OpenRepo(context.Background(), w.RepoRoot)
+	if err != nil {
+		return ""
+	}
+
+	ref, err := repo.Reference(stashRefName(w.Branch), true)
+	if err != nil {
+		return ""
+	}
+
+	return ref.Name().String()
+}
 
 // DiscoverWorkspaces discovers all workspaces using git worktree list.
 func DiscoverWorkspaces(repoRoot string) ([]Workspace, error) {
@@ -104,7 +144,7 @@ func GetWorkspacesBasePath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	repoName, err := git.GetRepoName()
+	repoName, err := git.GetRepoName(context.Background())
 	if err != nil {
 		return "", err
 	}